@@ -0,0 +1,112 @@
+package mpv
+
+import "strings"
+
+// AudioParams are the audio-params and audio-out-params properties.
+type AudioParams struct {
+	Format       string `json:"format"` // Sample format, e.g. "floatp"
+	SampleRate   int    `json:"samplerate"`
+	Channels     string `json:"channels"`    // Channel layout, e.g. "5.1"
+	HRChannels   string `json:"hr-channels"` // Channel layout for display
+	ChannelCount int    `json:"channel-count"`
+}
+
+// AudioParams returns the parameters of the decoded audio after filtering.
+func (c *Client) AudioParams() (*AudioParams, error) {
+	return c.audioParams("audio-params")
+}
+
+// AudioOutParams returns the parameters negotiated with the audio output.
+func (c *Client) AudioOutParams() (*AudioParams, error) {
+	return c.audioParams("audio-out-params")
+}
+
+func (c *Client) audioParams(property string) (*AudioParams, error) {
+	p := &AudioParams{}
+	if err := c.GetPropertyInto(property, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// AudioCodecName returns the short name of the audio codec, e.g. "aac".
+func (c *Client) AudioCodecName() (string, error) {
+	return c.GetStringProperty("audio-codec-name")
+}
+
+// ReplayGain modes for SetReplayGain
+const (
+	ReplayGainNo    = "no"
+	ReplayGainTrack = "track"
+	ReplayGainAlbum = "album"
+)
+
+// SetReplayGain selects whether ReplayGain tags of the track or album are
+// applied to the volume.
+func (c *Client) SetReplayGain(mode string) error {
+	return c.SetProperty("replaygain", mode)
+}
+
+// SetReplayGainPreamp sets the gain in dB added to the ReplayGain.
+func (c *Client) SetReplayGainPreamp(db float64) error {
+	return c.SetProperty("replaygain-preamp", db)
+}
+
+// loudnormLabel identifies the filter of EnableLoudnessNormalization in the af chain.
+const loudnormLabel = "@mpv-go-loudnorm"
+
+// EnableLoudnessNormalization adds ffmpeg's loudnorm filter, which evens
+// out the loudness of files without ReplayGain tags.
+func (c *Client) EnableLoudnessNormalization() error {
+	return c.command("af", "add", loudnormLabel+":lavfi-loudnorm")
+}
+
+// DisableLoudnessNormalization removes the filter added by
+// EnableLoudnessNormalization.
+func (c *Client) DisableLoudnessNormalization() error {
+	return c.command("af", "remove", loudnormLabel)
+}
+
+// SetAudioChannels sets the channel layout sent to the audio output, e.g.
+// "stereo" to downmix surround sound, or "auto-safe" (the default) to use
+// what the output supports.
+func (c *Client) SetAudioChannels(layout string) error {
+	return c.SetProperty("audio-channels", layout)
+}
+
+// SetAudioNormalizeDownmix enables normalizing the volume when downmixing,
+// which avoids clipping but makes the downmix quieter.
+func (c *Client) SetAudioNormalizeDownmix(enable bool) error {
+	return c.SetProperty("audio-normalize-downmix", enable)
+}
+
+// SetAudioExclusive requests exclusive access to the audio device, for
+// bit-perfect output bypassing the system mixer where supported.
+func (c *Client) SetAudioExclusive(enable bool) error {
+	return c.SetProperty("audio-exclusive", enable)
+}
+
+// IsAudioExclusive returns true if exclusive access to the audio device is requested.
+func (c *Client) IsAudioExclusive() (bool, error) {
+	return c.GetBoolProperty("audio-exclusive")
+}
+
+// SetAudioSPDIF passes audio of the given codecs through to the receiver
+// undecoded, e.g. "ac3", "dts", "eac3", "truehd" or "dts-hd". No codecs
+// disable passthrough.
+func (c *Client) SetAudioSPDIF(codecs ...string) error {
+	return c.SetProperty("audio-spdif", strings.Join(codecs, ","))
+}
+
+// AudioPassthrough returns the codec passed through to the receiver, e.g.
+// "ac3", or "" if the audio is decoded.
+func (c *Client) AudioPassthrough() (string, error) {
+	p, err := c.AudioOutParams()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(p.Format, "spdif-") {
+		return "", nil
+	}
+	return strings.TrimPrefix(p.Format, "spdif-"), nil
+}
@@ -0,0 +1,44 @@
+package mpv
+
+// Chapter is an entry of the chapter-list property.
+type Chapter struct {
+	Title string  `json:"title"`
+	Start float64 `json:"time"` // Start in seconds
+}
+
+// Chapters returns the chapters of the current file.
+func (c *Client) Chapters() ([]Chapter, error) {
+	var chapters []Chapter
+	if err := c.GetPropertyInto("chapter-list", &chapters); err != nil {
+		return nil, err
+	}
+	return chapters, nil
+}
+
+// ChapterCount returns the number of chapters of the current file.
+func (c *Client) ChapterCount() (int, error) {
+	n, err := c.GetFloatProperty("chapters")
+	return int(n), err
+}
+
+// CurrentChapter returns the index of the current chapter. It is -1 before
+// the first chapter.
+func (c *Client) CurrentChapter() (int, error) {
+	n, err := c.GetFloatProperty("chapter")
+	return int(n), err
+}
+
+// GoToChapter seeks to the start of the chapter with index i.
+func (c *Client) GoToChapter(i int) error {
+	return c.SetProperty("chapter", i)
+}
+
+// NextChapter seeks to the next chapter.
+func (c *Client) NextChapter() error {
+	return c.command("add", "chapter", 1)
+}
+
+// PrevChapter seeks to the previous chapter.
+func (c *Client) PrevChapter() error {
+	return c.command("add", "chapter", -1)
+}
@@ -1,21 +1,129 @@
 package mpv
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // Client is a more comfortable higher level interface
 // to LLClient. It can use any LLClient implementation.
 type Client struct {
 	LLClient
+	observers *observers
+	logLevel  *int32 // Level requested by EnableLogMessages, -1 if never requested
+	handlers  *handlers
+	osd       string // OSD prefix of commands
 }
 
 // NewClient creates a new highlevel client based on a lowlevel client.
+// Property observers and log messages are restored automatically when the
+// lowlevel client reconnects or fails over.
 func NewClient(llClient LLClient) *Client {
-	return &Client{
-		LLClient: llClient,
+	logLevel := int32(-1)
+	c := &Client{
+		LLClient:  llClient,
+		observers: newObservers(),
+		logLevel:  &logLevel,
+		handlers:  &handlers{byName: make(map[string]map[int]func())},
 	}
+	llClient.RegisterEvent(EventReconnected, c.resubscribe)
+	llClient.RegisterEvent(EventBackendChanged, c.resubscribe)
+	return c
+}
+
+// Done returns a channel which is closed when mpv shuts down or the
+// connection to it is lost, if the lowlevel client supports it like
+// IPCClient does. Otherwise it returns nil.
+func (c *Client) Done() <-chan struct{} {
+	if d, ok := c.LLClient.(interface{ Done() <-chan struct{} }); ok {
+		return d.Done()
+	}
+	return nil
+}
+
+// Err returns nil while Done is open and why it was closed afterwards, see
+// IPCClient.Err. It returns nil if the lowlevel client does not support Done.
+func (c *Client) Err() error {
+	if e, ok := c.LLClient.(interface{ Err() error }); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+// ExecContext executes a command like Exec but stops waiting for the
+// response when ctx is done. If the lowlevel client supports it like
+// IPCClient does, the command runs asynchronously in mpv. Otherwise it falls
+// back to Exec, which still times out on its own.
+func (c *Client) ExecContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	if e, ok := c.LLClient.(interface {
+		ExecContext(context.Context, ...interface{}) (*Response, error)
+	}); ok {
+		return e.ExecContext(ctx, command...)
+	}
+	type result struct {
+		res *Response
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		res, err := c.Exec(command...)
+		results <- result{res, err}
+	}()
+	select {
+	case r := <-results:
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// OSD modes controlling the on screen display feedback of commands
+const (
+	OSDAuto   = "osd-auto"    // Default behavior of the command
+	OSDNone   = "no-osd"      // No OSD
+	OSDBar    = "osd-bar"     // Show the progress bar where possible
+	OSDMsg    = "osd-msg"     // Show a message where possible
+	OSDMsgBar = "osd-msg-bar" // Show the progress bar and a message
+)
+
+// SetDefaultOSD sets the OSD mode used by commands of this client, e.g.
+// OSDNone to suppress all feedback of a headless player. Property getters
+// and setters are not affected. Set it before issuing commands, it is not
+// synchronized with concurrent calls.
+func (c *Client) SetDefaultOSD(mode string) {
+	if mode == OSDAuto {
+		mode = ""
+	}
+	c.osd = mode
+}
+
+// WithOSD returns a client sharing the connection and observers of c which
+// sends commands with the given OSD mode, to override the default per call:
+//
+//	c.WithOSD(mpv.OSDMsgBar).Seek(10, mpv.SeekOptions{})
+func (c *Client) WithOSD(mode string) *Client {
+	cc := *c
+	cc.SetDefaultOSD(mode)
+	return &cc
+}
+
+// Close stops all property observers and closes the lowlevel client
+// if it supports closing.
+func (c *Client) Close() error {
+	err := c.unobserveAll()
+	if closer, ok := c.LLClient.(io.Closer); ok {
+		if cerr := closer.Close(); cerr != nil {
+			return cerr
+		}
+	}
+	return err
 }
 
 // Mode options for Loadfile
@@ -23,6 +131,11 @@ const (
 	LoadFileModeReplace    = "replace"
 	LoadFileModeAppend     = "append"
 	LoadFileModeAppendPlay = "append-play" // Starts if nothing is playing
+	// Modes of mpv 0.38 and later
+	LoadFileModeInsertNext     = "insert-next"      // Insert after the current entry
+	LoadFileModeInsertNextPlay = "insert-next-play" // Like LoadFileModeInsertNext, starts if nothing is playing
+	LoadFileModeInsertAt       = "insert-at"        // Insert at the index passed to LoadFileIndex
+	LoadFileModeInsertAtPlay   = "insert-at-play"   // Like LoadFileModeInsertAt, starts if nothing is playing
 )
 
 // Loadfile loads a file, it either replaces the currently playing file (LoadFileModeReplace),
@@ -32,76 +145,189 @@ func (c *Client) LoadFile(path string, mode string) error {
 	if mode == "" {
 		mode = "append-play"
 	}
-	_, err := c.Exec("loadfile", path, mode)
-	return err
+	if err := c.requireLoadFileMode(mode); err != nil {
+		return err
+	}
+	return c.send("loadfile", path, mode)
+}
+
+// LoadFileIndex loads a file like LoadFile and passes the playlist index
+// used by LoadFileModeInsertAt and LoadFileModeInsertAtPlay. Other modes
+// ignore the index. It returns ErrUnsupported before mpv 0.38, which
+// took the options at the position of the index.
+func (c *Client) LoadFileIndex(path string, mode string, index int) error {
+	if mode == "" {
+		mode = "append-play"
+	}
+	if err := c.requireVersion(0, 38); err != nil {
+		return err
+	}
+	return c.command("loadfile", path, mode, index)
+}
+
+// LoadFileOptions loads a file like LoadFile and sets options only while
+// the file plays, e.g. {"start": "30"} or NetworkOptions.Options().
+func (c *Client) LoadFileOptions(path string, mode string, options map[string]string) error {
+	if mode == "" {
+		mode = "append-play"
+	}
+	if err := c.requireLoadFileMode(mode); err != nil {
+		return err
+	}
+	// Named arguments, the position of options differs between mpv versions
+	return c.command(map[string]interface{}{
+		"name":    "loadfile",
+		"url":     path,
+		"flags":   mode,
+		"options": formatOptions(options),
+	})
+}
+
+// requireLoadFileMode returns ErrUnsupported for the insert modes before mpv 0.38.
+func (c *Client) requireLoadFileMode(mode string) error {
+	if !strings.HasPrefix(mode, "insert-") {
+		return nil
+	}
+	return c.requireVersion(0, 38)
+}
+
+// formatOptions formats options as a key-value list. Values are escaped
+// with mpv's %length% syntax, so they may contain commas.
+func formatOptions(options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]string, len(keys))
+	for i, k := range keys {
+		v := options[k]
+		list[i] = k + "=%" + strconv.Itoa(len(v)) + "%" + v
+	}
+	return strings.Join(list, ",")
 }
 
 // Mode options for Seek
 const (
-	SeekModeRelative = "relative"
-	SeekModeAbsolute = "absolute"
+	SeekModeRelative        = "relative"
+	SeekModeAbsolute        = "absolute"
+	SeekModeRelativePercent = "relative-percent"
+	SeekModeAbsolutePercent = "absolute-percent"
 )
 
-// Seek seeks to a position in the current file.
-func (c *Client) Seek(n int) error {
-	_, err := c.Exec("seek", n)
-	return err
+// Precision options for Seek
+const (
+	SeekExact     = "exact"     // Seek to the exact position, decoding from the previous keyframe
+	SeekKeyframes = "keyframes" // Seek to the nearest keyframe
+)
+
+// SeekOptions control how Seek interprets its target. The zero value seeks
+// relative to the current position with mpv's default precision.
+type SeekOptions struct {
+	Mode      string // SeekModeRelative if empty
+	Precision string // SeekExact, SeekKeyframes or mpv's default if empty
+}
+
+// Seek seeks in the current file. Depending on the mode, target is in
+// seconds or in percent of the duration.
+func (c *Client) Seek(target float64, opts SeekOptions) error {
+	flags := opts.Mode
+	if flags == "" {
+		flags = SeekModeRelative
+	}
+	if opts.Precision != "" {
+		flags += "+" + opts.Precision
+	}
+	return c.command("seek", target, flags)
 }
 
 // PlaylistNext plays the next playlistitem or NOP if no item is available.
 func (c *Client) PlayNext() error {
-	_, err := c.Exec("playlist-next")
-	return err
+	return c.send("playlist-next")
 }
 
 // PlaylistPrevious plays the previous playlistitem or NOP if no item is available.
 func (c *Client) PlayPrev() error {
-	_, err := c.Exec("playlist-prev")
-	return err
+	return c.send("playlist-prev")
 }
 
 // Return Playlist Current Pos
 func (c *Client) PlayPos() int {
-	n, _ := c.GetFloatProperty("playlist-pos")
-	return int(n)
+	v, _ := c.PlayPosE()
+	return v
+}
+
+// PlayPosE is like PlayPos but also returns the error of reading the property.
+func (c *Client) PlayPosE() (int, error) {
+	n, err := c.GetFloatProperty("playlist-pos")
+	return int(n), err
+}
+
+// ErrPlaylistRange is returned by SetPlayPos if the position is not an
+// index of the playlist.
+var ErrPlaylistRange = errors.New("Playlist position out of range")
+
+// SetPlayPos sets the playlist-pos property to n after checking it against
+// the playlist-count. Unlike PlayIndex, setting the current position again
+// does not restart the entry.
+func (c *Client) SetPlayPos(n int) error {
+	count, err := c.PlaylistCountE()
+	if err != nil {
+		return err
+	}
+	if n < 0 || n >= count {
+		return ErrPlaylistRange
+	}
+	return c.SetProperty("playlist-pos", n)
 }
 
 // Return Playlist
 func (c *Client) Playlist() []string {
+	names, _ := c.PlaylistE()
+	return names
+}
+
+// PlaylistE is like Playlist but also returns the error of reading the property.
+func (c *Client) PlaylistE() ([]string, error) {
+	res, err := c.getProperty("playlist")
+	if err != nil {
+		return nil, err
+	}
+	entries, ok := res.Data.([]interface{})
+	if !ok {
+		return nil, ErrInvalidType
+	}
 	var names []string
-	resp, _ := c.Exec("get_property", "playlist")
-	for _, v := range resp.Data.([]interface{}) {
-		for k, v1 := range v.(map[string]interface{}) {
-			if k == "filename" {
-				names = append(names, v1.(string))
-			}
+	for _, v := range entries {
+		entry, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidType
+		}
+		if name, ok := entry["filename"].(string); ok {
+			names = append(names, name)
 		}
 	}
-	return names
+	return names, nil
 }
 
 // Remove current Playlist
 func (c *Client) PlayRemove() error {
-	_, err := c.Exec("playlist-remove")
-	return err
+	return c.send("playlist-remove")
 }
 
 // Remove the specified playlistitem
 func (c *Client) PlayIndexRemove(n int) error {
-	_, err := c.Exec("playlist-remove", n)
-	return err
+	return c.send("playlist-remove", n)
 }
 
 // Clear Playlist (keep the playing)
 func (c *Client) PlayClear() error {
-	_, err := c.Exec("playlist-clear")
-	return err
+	return c.send("playlist-clear")
 }
 
 // Play the specified item
 func (c *Client) PlayIndex(n int) error {
-	_, err := c.Exec("playlist-play-index", n)
-	return err
+	return c.send("playlist-play-index", n)
 }
 
 // loop-playlist
@@ -116,26 +342,39 @@ func (c *Client) PlayUnLoop() error {
 
 // Return playloop status
 func (c *Client) IsPlayLoop() bool {
-	b := c.GetProperty("loop-playlist")
-	return b == "inf"
+	v, _ := c.IsPlayLoopE()
+	return v
+}
+
+// IsPlayLoopE is like IsPlayLoop but also returns the error of reading the property.
+func (c *Client) IsPlayLoopE() (bool, error) {
+	res, err := c.getProperty("loop-playlist")
+	if err != nil {
+		return false, err
+	}
+	return res.Data == "inf", nil // false or a loop count otherwise
 }
 
 // Shuffle the playlist
 func (c *Client) PlayShuffle() error {
-	_, err := c.Exec("playlist-suffle")
-	return err
+	return c.send("playlist-shuffle")
 }
 
 // UnShuffle the playlist
 func (c *Client) PlayUnShuffle() error {
-	_, err := c.Exec("playlist-unshuffle")
-	return err
+	return c.send("playlist-unshuffle")
 }
 
 // Return the playlist-count
 func (c *Client) PlaylistCount() int {
-	n, _ := c.GetFloatProperty("playlist-count")
-	return int(n)
+	v, _ := c.PlaylistCountE()
+	return v
+}
+
+// PlaylistCountE is like PlaylistCount but also returns the error of reading the property.
+func (c *Client) PlaylistCountE() (int, error) {
+	n, err := c.GetFloatProperty("playlist-count")
+	return int(n), err
 }
 
 // Mode options for LoadList
@@ -150,11 +389,13 @@ func (c *Client) LoadList(path string, mode string) error {
 	if mode == "" {
 		mode = "replace"
 	}
-	_, err := c.Exec("loadlist", path, mode)
-	return err
+	return c.send("loadlist", path, mode)
 }
 
 // GetProperty reads a property by name and returns the data as a string.
+//
+// Deprecated: GetProperty hides errors and formats non-string data with %#v,
+// e.g. "<nil>" for unavailable properties. Use GetStringProperty.
 func (c *Client) GetProperty(name string) string {
 	res, _ := c.Exec("get_property", name)
 	if res == nil {
@@ -166,96 +407,407 @@ func (c *Client) GetProperty(name string) string {
 	return fmt.Sprintf("%#v", res.Data)
 }
 
-// SetProperty sets the value of a property.
+// SetProperty sets the value of a property. It returns a PropertyError if
+// mpv rejects the property or the value.
 func (c *Client) SetProperty(name string, value interface{}) error {
-	_, err := c.Exec("set_property", name, value)
-	return err
+	res, err := c.Exec("set_property", name, value)
+	if err != nil {
+		return err
+	}
+	if res.Err != "success" {
+		return &PropertyError{Name: name, Err: res.Err, Response: res}
+	}
+	return nil
+}
+
+// Directions for Cycle
+const (
+	CycleUp   = "up"
+	CycleDown = "down"
+)
+
+// Cycle cycles a property to its next value, e.g. "osd-level" or "hwdec".
+// The optional direction is CycleUp, the default, or CycleDown to go to the
+// previous value instead.
+func (c *Client) Cycle(property string, direction ...string) error {
+	args := []interface{}{"cycle", property}
+	for _, d := range direction {
+		args = append(args, d)
+	}
+	return c.command(args...)
+}
+
+// CycleValues sets a property to the next of the given values, e.g. to
+// toggle between speeds 1, 1.5 and 2. mpv expects string arguments, so
+// values are formatted with fmt.Sprint and booleans as "yes" or "no".
+func (c *Client) CycleValues(property string, values ...interface{}) error {
+	args := []interface{}{"cycle-values", property}
+	for _, v := range values {
+		switch v := v.(type) {
+		case bool:
+			if v {
+				args = append(args, "yes")
+			} else {
+				args = append(args, "no")
+			}
+		default:
+			args = append(args, fmt.Sprint(v))
+		}
+	}
+	return c.command(args...)
 }
 
 // ErrInvalidType is returned if the response data does not match the methods return type.
 // Use GetProperty or find matching type in mpv docs.
 var ErrInvalidType = errors.New("Invalid type")
 
+// ErrInvalidValue is returned if a value is rejected before sending it to mpv.
+var ErrInvalidValue = errors.New("Invalid value")
+
+// CommandError is returned if mpv answered a command with an error.
+type CommandError struct {
+	Command string // Name of the command, e.g. "loadfile"
+	Err     string // Error string returned by mpv
+}
+
+func (e *CommandError) Error() string {
+	return e.Command + ": " + e.Err
+}
+
+// exec executes a command and returns a CommandError if mpv reports an error.
+func (c *Client) exec(command ...interface{}) (*Response, error) {
+	res, err := c.Exec(command...)
+	if err != nil {
+		return nil, err
+	}
+	return res, responseError(command, res)
+}
+
+// execContext executes a command asynchronously with ExecContext and returns
+// a CommandError if mpv reports an error.
+func (c *Client) execContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	res, err := c.ExecContext(ctx, command...)
+	if err != nil {
+		return nil, err
+	}
+	return res, responseError(command, res)
+}
+
+// command executes an input command with the client's OSD prefix.
+func (c *Client) command(command ...interface{}) error {
+	res, err := c.Exec(c.withOSD(command)...)
+	if err != nil {
+		return err
+	}
+	return responseError(command, res)
+}
+
+// send executes an input command with the client's OSD prefix like command,
+// but only returns errors of the communication with mpv and ignores mpv's
+// reply. The wrappers of the original API keep this behavior, e.g. PlayNext
+// stays a no-op at the end of the playlist.
+func (c *Client) send(command ...interface{}) error {
+	_, err := c.Exec(c.withOSD(command)...)
+	return err
+}
+
+// commandContext is like command but runs the command asynchronously with
+// ExecContext.
+func (c *Client) commandContext(ctx context.Context, command ...interface{}) error {
+	res, err := c.ExecContext(ctx, c.withOSD(command)...)
+	if err != nil {
+		return err
+	}
+	return responseError(command, res)
+}
+
+// withOSD adds the client's OSD prefix to the command.
+func (c *Client) withOSD(command []interface{}) []interface{} {
+	if c.osd == "" {
+		return command
+	}
+	if named, ok := command[0].(map[string]interface{}); ok && len(command) == 1 {
+		// Named arguments take prefixes as _flags
+		withFlags := map[string]interface{}{"_flags": []interface{}{c.osd}}
+		for k, v := range named {
+			withFlags[k] = v
+		}
+		return []interface{}{withFlags}
+	}
+	return append([]interface{}{c.osd}, command...)
+}
+
+// responseError returns a CommandError if mpv answered the command with an error.
+func responseError(command []interface{}, res *Response) error {
+	if res.Err != "success" {
+		return &CommandError{Command: commandName(command), Err: res.Err}
+	}
+	return nil
+}
+
+// ErrPropertyUnavailable matches a PropertyError for a property which exists
+// but has no value right now, e.g. time-pos while nothing is playing.
+// Check for it with errors.Is.
+var ErrPropertyUnavailable = errors.New("Property unavailable")
+
+// PropertyError is returned if mpv fails to get or set a property.
+type PropertyError struct {
+	Name     string    // Name of the property
+	Err      string    // Error string returned by mpv
+	Response *Response // Response as received from mpv
+}
+
+func (e *PropertyError) Error() string {
+	return "property " + e.Name + ": " + e.Err
+}
+
+// Is reports whether target is ErrPropertyUnavailable and the property is
+// unavailable, or ErrUnsupported and mpv does not know the property.
+func (e *PropertyError) Is(target error) bool {
+	switch target {
+	case ErrPropertyUnavailable:
+		return e.Err == "property unavailable"
+	case ErrUnsupported:
+		return e.Err == "property not found"
+	}
+	return false
+}
+
+// getProperty reads a property and returns a PropertyError if mpv reports an error.
+func (c *Client) getProperty(name string) (*Response, error) {
+	res, err := c.Exec("get_property", name)
+	if err != nil {
+		return nil, err
+	}
+	if res.Err != "success" {
+		return res, &PropertyError{Name: name, Err: res.Err, Response: res}
+	}
+	return res, nil
+}
+
+// GetStringProperty reads a string property. It returns ErrInvalidType if
+// the data is not a string.
+func (c *Client) GetStringProperty(name string) (string, error) {
+	res, err := c.getProperty(name)
+	if err != nil {
+		return "", err
+	}
+	if val, found := res.Data.(string); found {
+		return val, nil
+	}
+	return "", ErrInvalidType
+}
+
 // GetFloatProperty reads a float property and returns the data as a float64.
 func (c *Client) GetFloatProperty(name string) (float64, error) {
-	res, err := c.Exec("get_property", name)
-	if res == nil {
+	res, err := c.getProperty(name)
+	if err != nil {
 		return 0, err
 	}
 	if val, found := res.Data.(float64); found {
-		return val, err
+		return val, nil
 	}
 	return 0, ErrInvalidType
 }
 
 // GetBoolProperty reads a bool property and returns the data as a boolean.
 func (c *Client) GetBoolProperty(name string) (bool, error) {
-	res, err := c.Exec("get_property", name)
-	if res == nil {
+	res, err := c.getProperty(name)
+	if err != nil {
 		return false, err
 	}
 	if val, found := res.Data.(bool); found {
-		return val, err
+		return val, nil
 	}
 	return false, ErrInvalidType
 }
 
+// GetPropertyInto reads a property and decodes its data into v like
+// json.Unmarshal, e.g. into a struct with json tags matching the fields of
+// the track-list entries.
+func (c *Client) GetPropertyInto(name string, v interface{}) error {
+	res, err := c.getProperty(name)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(res.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// GetProperties reads several properties at once. The requests are sent
+// concurrently, so they are pipelined instead of waiting for each response
+// in turn. Unavailable properties are set to nil in the result; any other
+// error is returned together with the properties read successfully.
+func (c *Client) GetProperties(names ...string) (map[string]interface{}, error) {
+	type result struct {
+		name string
+		data interface{}
+		err  error
+	}
+	results := make(chan result, len(names))
+	for _, name := range names {
+		go func(name string) {
+			res, err := c.getProperty(name)
+			if err != nil {
+				results <- result{name: name, err: err}
+				return
+			}
+			results <- result{name: name, data: res.Data}
+		}(name)
+	}
+	props := make(map[string]interface{}, len(names))
+	var firstErr error
+	for range names {
+		r := <-results
+		switch {
+		case r.err == nil, errors.Is(r.err, ErrPropertyUnavailable):
+			props[r.name] = r.data
+		case firstErr == nil:
+			firstErr = r.err
+		}
+	}
+	return props, firstErr
+}
+
 // Filename returns the currently playing filename
 func (c *Client) CurrentFile() string {
-	return c.GetProperty("filename")
+	v, _ := c.CurrentFileE()
+	return v
+}
+
+// CurrentFileE is like CurrentFile but also returns the error of reading the property.
+func (c *Client) CurrentFileE() (string, error) {
+	s, err := c.GetStringProperty("filename")
+	return s, err
 }
 
 // Path returns the currently playing path
 func (c *Client) CurerentFileWithPath() string {
-	return c.GetProperty("path")
+	v, _ := c.CurerentFileWithPathE()
+	return v
+}
+
+// CurerentFileWithPathE is like CurerentFileWithPath but also returns the error of reading the property.
+func (c *Client) CurerentFileWithPathE() (string, error) {
+	s, err := c.GetStringProperty("path")
+	return s, err
 }
 
 // Pause returns true if the player is paused
 func (c *Client) IsPause() bool {
-	b, _ := c.GetBoolProperty("pause")
-	return b
+	v, _ := c.IsPauseE()
+	return v
 }
 
-// SetPause pauses or unpauses the player
+// IsPauseE is like IsPause but also returns the error of reading the property.
+func (c *Client) IsPauseE() (bool, error) {
+	b, err := c.GetBoolProperty("pause")
+	return b, err
+}
+
+// Pause toggles the pause state like TogglePause. Use SetPause to pause
+// regardless of the current state.
 func (c *Client) Pause() error {
-	_, err := c.Exec("cycle", "pause")
-	return err
+	return c.TogglePause()
+}
+
+// TogglePause pauses or unpauses the player.
+func (c *Client) TogglePause() error {
+	return c.command("cycle", "pause")
+}
+
+// SetPause pauses the player if pause is true, otherwise it resumes playback.
+func (c *Client) SetPause(pause bool) error {
+	return c.SetProperty("pause", pause)
+}
+
+// Play resumes playback.
+func (c *Client) Play() error {
+	return c.SetPause(false)
 }
 
 // Idle returns true if the player is idle
 func (c *Client) IsIdle() bool {
-	b, _ := c.GetBoolProperty("idle")
-	return b
+	v, _ := c.IsIdleE()
+	return v
+}
+
+// IsIdleE is like IsIdle but also returns the error of reading the property.
+func (c *Client) IsIdleE() (bool, error) {
+	b, err := c.GetBoolProperty("idle")
+	return b, err
 }
 
 // Mute returns true if the player is muted.
 func (c *Client) IsMute() bool {
-	b, _ := c.GetBoolProperty("mute")
-	return b
+	v, _ := c.IsMuteE()
+	return v
+}
+
+// IsMuteE is like IsMute but also returns the error of reading the property.
+func (c *Client) IsMuteE() (bool, error) {
+	b, err := c.GetBoolProperty("mute")
+	return b, err
 }
 
-// SetMute mutes or unmutes the player.
+// Mute toggles mute like ToggleMute. Use SetMute to mute regardless of
+// the current state.
 func (c *Client) Mute() error {
-	_, err := c.Exec("cycle", "mute")
-	return err
+	return c.ToggleMute()
+}
+
+// ToggleMute mutes or unmutes the player.
+func (c *Client) ToggleMute() error {
+	return c.command("cycle", "mute")
+}
+
+// SetMute mutes the player if mute is true, otherwise it unmutes it.
+func (c *Client) SetMute(mute bool) error {
+	return c.SetProperty("mute", mute)
 }
 
 // Fullscreen returns true if the player is in fullscreen mode.
 func (c *Client) IsFullscreen() bool {
-	b, _ := c.GetBoolProperty("fullscreen")
-	return b
+	v, _ := c.IsFullscreenE()
+	return v
+}
+
+// IsFullscreenE is like IsFullscreen but also returns the error of reading the property.
+func (c *Client) IsFullscreenE() (bool, error) {
+	b, err := c.GetBoolProperty("fullscreen")
+	return b, err
 }
 
-// SetFullscreen activates/deactivates the fullscreen mode.
+// Fullscreen toggles the fullscreen mode like ToggleFullscreen. Use
+// SetFullscreen to set it regardless of the current state.
 func (c *Client) Fullscreen() error {
-	_, err := c.Exec("cycle", "fullscreen")
-	return err
+	return c.ToggleFullscreen()
+}
+
+// ToggleFullscreen activates/deactivates the fullscreen mode.
+func (c *Client) ToggleFullscreen() error {
+	return c.command("cycle", "fullscreen")
+}
+
+// SetFullscreen activates the fullscreen mode if fullscreen is true,
+// otherwise it deactivates it.
+func (c *Client) SetFullscreen(fullscreen bool) error {
+	return c.SetProperty("fullscreen", fullscreen)
 }
 
 // Volume returns the current volume level.
 func (c *Client) CurrentVolume() int {
-	v, _ := c.GetFloatProperty("volume")
-	return int(v)
+	v, _ := c.CurrentVolumeE()
+	return v
+}
+
+// CurrentVolumeE is like CurrentVolume but also returns the error of reading the property.
+func (c *Client) CurrentVolumeE() (int, error) {
+	v, err := c.GetFloatProperty("volume")
+	return int(v), err
 }
 
 // Set Volume level
@@ -265,8 +817,14 @@ func (c *Client) Volume(level int) error {
 
 // Speed returns the current playback speed.
 func (c *Client) CurrentSpeed() float64 {
-	s, _ := c.GetFloatProperty("speed")
-	return s
+	v, _ := c.CurrentSpeedE()
+	return v
+}
+
+// CurrentSpeedE is like CurrentSpeed but also returns the error of reading the property.
+func (c *Client) CurrentSpeedE() (float64, error) {
+	s, err := c.GetFloatProperty("speed")
+	return s, err
 }
 
 // Set playback speed
@@ -276,25 +834,104 @@ func (c *Client) Speed(n float64) error {
 
 // Duration returns the duration of the currently playing file.
 func (c *Client) Duration() float64 {
-	v, _ := c.GetFloatProperty("duration")
+	v, _ := c.DurationE()
 	return v
 }
 
+// DurationE is like Duration but also returns the error of reading the property.
+func (c *Client) DurationE() (float64, error) {
+	v, err := c.GetFloatProperty("duration")
+	return v, err
+}
+
 // Position returns the current playback position in seconds.
 func (c *Client) Position() float64 {
-	b, _ := c.GetFloatProperty("time-pos")
-	return b
+	v, _ := c.PositionE()
+	return v
+}
+
+// PositionE is like Position but also returns the error of reading the property.
+func (c *Client) PositionE() (float64, error) {
+	b, err := c.GetFloatProperty("time-pos")
+	return b, err
 }
 
 // PercentPosition returns the current playback position in percent.
 func (c *Client) PercentPosition() float64 {
-	b, _ := c.GetFloatProperty("percent-pos")
-	return b
+	v, _ := c.PercentPositionE()
+	return v
 }
 
-// Register Event HandFunc
-func (c *Client) RegisterEvent(eventName string, handle func()) {
-	c.LLClient.RegisterEvent(eventName, handle)
+// PercentPositionE is like PercentPosition but also returns the error of reading the property.
+func (c *Client) PercentPositionE() (float64, error) {
+	b, err := c.GetFloatProperty("percent-pos")
+	return b, err
+}
+
+// SetPercentPosition seeks to p percent of the current file by setting the
+// percent-pos property.
+func (c *Client) SetPercentPosition(p float64) error {
+	return c.SetProperty("percent-pos", p)
+}
+
+// SeekPercent seeks to p percent (0-100) of the current file, e.g. for a
+// slider, without reading the duration first.
+func (c *Client) SeekPercent(p float64) error {
+	return c.command("seek", p, "absolute-percent")
+}
+
+// handlers tracks the event handlers registered with Client.RegisterEvent,
+// so UnregisterEvent leaves the handlers of the library itself in place.
+type handlers struct {
+	mu     sync.Mutex
+	byName map[string]map[int]func() // Unregister functions by event and id
+	nextID int
+}
+
+// RegisterEvent registers a handler for the event. The handler receives the
+// event including its payload. The returned function removes the handler.
+func (c *Client) RegisterEvent(eventName string, handle func(Event)) func() {
+	unregister := c.LLClient.RegisterEvent(eventName, handle)
+	h := c.handlers
+	h.mu.Lock()
+	h.nextID++
+	id := h.nextID
+	if h.byName[eventName] == nil {
+		h.byName[eventName] = make(map[int]func())
+	}
+	h.byName[eventName][id] = unregister
+	h.mu.Unlock()
+	return func() {
+		h.mu.Lock()
+		delete(h.byName[eventName], id)
+		h.mu.Unlock()
+		unregister()
+	}
+}
+
+// UnregisterEvent removes all handlers registered for the event with
+// RegisterEvent. Handlers the client uses internally, e.g. for
+// ObserveProperty or to resubscribe after reconnecting, are not affected.
+func (c *Client) UnregisterEvent(eventName string) {
+	h := c.handlers
+	h.mu.Lock()
+	unregister := h.byName[eventName]
+	delete(h.byName, eventName)
+	h.mu.Unlock()
+	for _, fn := range unregister {
+		fn()
+	}
+}
+
+// callHandler calls fn outside of event dispatch, e.g. from a timer, like the
+// lowlevel client calls event handlers. IPCClient and FailoverClient report
+// a panic of fn as PanicError instead of crashing.
+func (c *Client) callHandler(fn func(Event), ev Event) {
+	if h, ok := c.LLClient.(interface{ callHandler(func(Event), Event) }); ok {
+		h.callHandler(fn, ev)
+		return
+	}
+	fn(ev)
 }
 
 // loop-file
@@ -309,64 +946,119 @@ func (c *Client) FileUnLoop() error { //"inf" is Infinite loop
 
 // Return fileloop status
 func (c *Client) IsFileLoop() bool {
-	b := c.GetProperty("loop-file")
-	return b == "inf"
+	v, _ := c.IsFileLoopE()
+	return v
+}
+
+// IsFileLoopE is like IsFileLoop but also returns the error of reading the property.
+func (c *Client) IsFileLoopE() (bool, error) {
+	res, err := c.getProperty("loop-file")
+	if err != nil {
+		return false, err
+	}
+	return res.Data == "inf", nil // false or a loop count otherwise
 }
 
 // time-remaining
 func (c *Client) TimeRemaining() float64 {
-	b, _ := c.GetFloatProperty("time-remaining")
-	return b
+	v, _ := c.TimeRemainingE()
+	return v
+}
+
+// TimeRemainingE is like TimeRemaining but also returns the error of reading the property.
+func (c *Client) TimeRemainingE() (float64, error) {
+	b, err := c.GetFloatProperty("time-remaining")
+	return b, err
 }
 
 // Playlist shuffle
 func (c *Client) Shuffle() error {
-	_, err := c.Exec("cycle", "shuffle")
-	return err
+	return c.send("cycle", "shuffle")
 }
 
 // Return shuffle status
 func (c *Client) IsShuffle() bool {
-	b, _ := c.GetBoolProperty("shuffle")
-	return b
+	v, _ := c.IsShuffleE()
+	return v
+}
+
+// IsShuffleE is like IsShuffle but also returns the error of reading the property.
+func (c *Client) IsShuffleE() (bool, error) {
+	b, err := c.GetBoolProperty("shuffle")
+	return b, err
 }
 
 // Get file-format
 func (c *Client) Format() string {
-	return c.GetProperty("file-format")
+	v, _ := c.FormatE()
+	return v
+}
+
+// FormatE is like Format but also returns the error of reading the property.
+func (c *Client) FormatE() (string, error) {
+	s, err := c.GetStringProperty("file-format")
+	return s, err
 }
 
 // Get Audio-bitrate
 func (c *Client) AudioBitrate() int {
-	v, _ := c.GetFloatProperty("audio-bitrate")
-	return int(v)
+	v, _ := c.AudioBitrateE()
+	return v
+}
+
+// AudioBitrateE is like AudioBitrate but also returns the error of reading the property.
+func (c *Client) AudioBitrateE() (int, error) {
+	v, err := c.GetFloatProperty("audio-bitrate")
+	return int(v), err
 }
 
 // Get Video-bitrate
 func (c *Client) VideoBitrate() int {
-	v, _ := c.GetFloatProperty("video-bitrate")
-	return int(v)
+	v, _ := c.VideoBitrateE()
+	return v
+}
+
+// VideoBitrateE is like VideoBitrate but also returns the error of reading the property.
+func (c *Client) VideoBitrateE() (int, error) {
+	v, err := c.GetFloatProperty("video-bitrate")
+	return int(v), err
 }
 
 // Get file-size
 func (c *Client) FileSize() int {
-	v, _ := c.GetFloatProperty("file-size")
-	return int(v)
+	v, _ := c.FileSizeE()
+	return v
+}
+
+// FileSizeE is like FileSize but also returns the error of reading the property.
+func (c *Client) FileSizeE() (int, error) {
+	v, err := c.GetFloatProperty("file-size")
+	return int(v), err
 }
 
 // Get media-title
 func (c *Client) MediaTitle() string {
-	return c.GetProperty("media-title")
+	v, _ := c.MediaTitleE()
+	return v
+}
+
+// MediaTitleE is like MediaTitle but also returns the error of reading the property.
+func (c *Client) MediaTitleE() (string, error) {
+	s, err := c.GetStringProperty("media-title")
+	return s, err
 }
 
 // Quit
 func (c *Client) Quit() error {
-	_, err := c.Exec("quit")
-	return err
+	return c.send("quit")
 }
 
 // Stop and clear playlist
 func (c *Client) Stop() error {
-	_, err := c.Exec("stop")
-	return err
+	return c.send("stop")
+}
+
+// StopKeepPlaylist stops playback like Stop but keeps the playlist.
+func (c *Client) StopKeepPlaylist() error {
+	return c.command("stop", "keep-playlist")
 }
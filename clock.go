@@ -0,0 +1,68 @@
+package mpv
+
+import "time"
+
+// Clock is the time source used for timeouts, tickers and delays.
+// Replace it with WithClock to test timing dependent code without real
+// sleeps. The Client, its throttled observers and the Watchdog use the
+// clock of the lowlevel client.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker delivers ticks like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer is a stoppable timer like time.Timer.
+type Timer interface {
+	Stop() bool
+}
+
+// clock returns the clock of the lowlevel client, like the one set with
+// WithClock, or SystemClock if it has none.
+func (c *Client) clock() Clock {
+	if cl, ok := c.LLClient.(interface{ Clock() Clock }); ok {
+		return cl.Clock()
+	}
+	return SystemClock
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,225 @@
+package mpv
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock which only advances when Advance is called.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a pending timer, ticker or AfterFunc of a fakeClock.
+type fakeTimer struct {
+	clock  *fakeClock
+	at     time.Time
+	period time.Duration // Set for tickers
+	ch     chan time.Time
+	fn     func()
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.add(d, 0, nil).ch
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	return fakeTicker{c.add(d, d, nil)}
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.add(d, 0, f)
+}
+
+func (c *fakeClock) add(d, period time.Duration, fn func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), period: period, ch: make(chan time.Time, 1), fn: fn}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires the timers due until then.
+// AfterFunc functions run on the calling goroutine.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	var due []*fakeTimer
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(now) {
+			pending = append(pending, t)
+			continue
+		}
+		due = append(due, t)
+		if t.period > 0 {
+			t.at = now.Add(t.period)
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+	for _, t := range due {
+		if t.fn != nil {
+			t.fn()
+			continue
+		}
+		select {
+		case t.ch <- now:
+		default: // Drop ticks like time.Ticker
+		}
+	}
+}
+
+// WaitTimers waits until n timers are pending, e.g. a goroutine created its ticker.
+func (c *fakeClock) WaitTimers(t *testing.T, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		pending := len(c.timers)
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timeout waiting for %d timers, %d pending", n, pending)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTicker struct {
+	*fakeTimer
+}
+
+func (t fakeTicker) Stop() {
+	t.fakeTimer.Stop()
+}
+
+// stubClient is an LLClient answering every command with success. Events
+// passed to emit are dispatched like an IPCClient dispatches mpv's events.
+type stubClient struct {
+	*IPCClient
+}
+
+func newStubClient(opts ...IPCOption) *stubClient {
+	return &stubClient{newIPCClient("", opts)}
+}
+
+func (s *stubClient) Exec(command ...interface{}) (*Response, error) {
+	return &Response{Err: "success"}, nil
+}
+
+func (s *stubClient) emit(ev Event) {
+	s.dispatchEvent(ev)
+}
+
+// emitChange emits a property-change event for the observe id.
+func (s *stubClient) emitChange(id int, value float64) {
+	s.emit(Event{
+		Name: EventPropertyChange,
+		Data: value,
+		Raw:  []byte(fmt.Sprintf(`{"event":"property-change","id":%d,"name":"time-pos","data":%v}`, id, value)),
+	})
+}
+
+func TestThrottleUsesClientClock(t *testing.T) {
+	clock := newFakeClock()
+	s := newStubClient(WithClock(clock), WithDispatchMode(DispatchSync))
+	c := NewClient(s)
+	var got []interface{}
+	id, err := c.ObserveProperty("time-pos", func(value interface{}) {
+		got = append(got, value)
+	}, Throttle(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.emitChange(id, 1)
+	s.emitChange(id, 2)
+	s.emitChange(id, 3)
+	if fmt.Sprint(got) != "[1]" {
+		t.Fatalf("Expected only the first change before the interval, got %v", got)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if fmt.Sprint(got) != "[1]" {
+		t.Fatalf("Expected no call within the interval, got %v", got)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if fmt.Sprint(got) != "[1 3]" {
+		t.Fatalf("Expected the latest change at the end of the interval, got %v", got)
+	}
+	clock.Advance(2 * time.Second)
+	s.emitChange(id, 4)
+	if fmt.Sprint(got) != "[1 3 4]" {
+		t.Fatalf("Expected a change after the interval right away, got %v", got)
+	}
+}
+
+func TestWatchdogReportsErrors(t *testing.T) {
+	clock := newFakeClock()
+	f := newFakeMPV(t, behaviorOf("mpv 0.38.0", 38)) // Rejects get_time_us
+	errs := make(chan error, 1)
+	client := NewIPCClient(f.ln.Addr().String(), WithClock(clock), WithErrorHandler(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	defer client.Close()
+
+	errRestart := errors.New("restart failed")
+	w := NewWatchdog(client, time.Second, 1)
+	w.Restart = func() error { return errRestart }
+	w.Start()
+	defer w.Stop()
+
+	clock.WaitTimers(t, 1)
+	clock.Advance(time.Second)
+	select {
+	case err := <-errs:
+		var werr *WatchdogError
+		if !errors.As(err, &werr) || werr.Op != "restart" || !errors.Is(err, errRestart) {
+			t.Fatalf("Expected a restart WatchdogError, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the watchdog error")
+	}
+}
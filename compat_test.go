@@ -0,0 +1,258 @@
+package mpv
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mpvBehavior is the recorded protocol behavior of an mpv version.
+type mpvBehavior struct {
+	version    string          // Value of mpv-version
+	properties map[string]bool // Properties mpv knows
+	modes      map[string]bool // Modes accepted by loadfile
+	index      bool            // loadfile takes the playlist index as third argument
+}
+
+var (
+	legacyModes = map[string]bool{"replace": true, "append": true, "append-play": true}
+	insertModes = map[string]bool{"replace": true, "append": true, "append-play": true,
+		"insert-next": true, "insert-next-play": true, "insert-at": true, "insert-at-play": true}
+)
+
+func behaviorOf(version string, minor int) mpvBehavior {
+	b := mpvBehavior{
+		version:    version,
+		properties: map[string]bool{"mpv-version": true, "pause": true, "deinterlace": true},
+		modes:      legacyModes,
+	}
+	if minor >= 36 {
+		b.properties["ab-loop-count"] = true
+	}
+	if minor >= 38 {
+		b.modes = insertModes
+		b.index = true
+	}
+	return b
+}
+
+var behaviors = []mpvBehavior{
+	behaviorOf("mpv 0.32.0", 32),
+	behaviorOf("mpv 0.33.1", 33),
+	behaviorOf("mpv 0.34.1", 34),
+	behaviorOf("mpv 0.35.1", 35),
+	behaviorOf("mpv 0.36.0", 36),
+	behaviorOf("mpv v0.37.0-543-g5a4b1d9", 37),
+	behaviorOf("mpv 0.38.0", 38),
+	behaviorOf("mpv 0.39.0", 39),
+	behaviorOf("mpv 0.40.0", 40),
+}
+
+// fakeMPV serves the IPC protocol on a unix socket as mpv with the behavior would.
+type fakeMPV struct {
+	mpvBehavior
+	ln net.Listener
+
+	mu       sync.Mutex
+	commands []string // Names of the received commands other than get_property
+}
+
+func newFakeMPV(t *testing.T, b mpvBehavior) *fakeMPV {
+	dir, err := os.MkdirTemp("", "mpv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	ln, err := net.Listen("unix", filepath.Join(dir, "socket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeMPV{mpvBehavior: b, ln: ln}
+	go f.serve()
+	return f
+}
+
+func (f *fakeMPV) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeMPV) handle(conn net.Conn) {
+	defer conn.Close()
+	sc := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for sc.Scan() {
+		var req struct {
+			Command   json.RawMessage `json:"command"`
+			RequestID int             `json:"request_id"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			continue
+		}
+		data, errStr := f.reply(req.Command)
+		enc.Encode(map[string]interface{}{"request_id": req.RequestID, "error": errStr, "data": data})
+	}
+}
+
+// reply answers a command like the recorded mpv version.
+func (f *fakeMPV) reply(raw json.RawMessage) (interface{}, string) {
+	var args []interface{}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		var named map[string]interface{}
+		if err := json.Unmarshal(raw, &named); err != nil {
+			return nil, "invalid parameter"
+		}
+		args = []interface{}{named["name"], named["url"], named["flags"]}
+	}
+	// Skip the OSD prefix
+	if prefix, _ := args[0].(string); strings.HasPrefix(prefix, "osd-") || prefix == "no-osd" {
+		args = args[1:]
+	}
+	name, _ := args[0].(string)
+	if name != "get_property" {
+		f.mu.Lock()
+		f.commands = append(f.commands, name)
+		f.mu.Unlock()
+	}
+	switch name {
+	case "get_property", "set_property":
+		prop, _ := args[1].(string)
+		if !f.properties[prop] {
+			return nil, "property not found"
+		}
+		if name == "set_property" {
+			return nil, "success"
+		}
+		switch prop {
+		case "mpv-version":
+			return f.version, "success"
+		case "deinterlace":
+			return false, "success"
+		}
+		return nil, "property unavailable"
+	case "loadfile":
+		mode, _ := args[2].(string)
+		if !f.modes[mode] {
+			return nil, "invalid parameter"
+		}
+		if len(args) > 3 && !f.index {
+			// Parsed as the options string
+			if _, ok := args[3].(string); !ok {
+				return nil, "invalid parameter"
+			}
+		}
+		return nil, "success"
+	}
+	return nil, "invalid parameter"
+}
+
+// sent reports whether the command reached mpv.
+func (f *fakeMPV) sent(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, cmd := range f.commands {
+		if cmd == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string // Command which must not be sent if unsupported
+		minor   int    // First supported 0.x version
+		call    func(c *Client) error
+	}{
+		{"LoadFileIndex", "loadfile", 38, func(c *Client) error {
+			return c.LoadFileIndex("a.mkv", LoadFileModeInsertAt, 1)
+		}},
+		{"LoadFileInsertNext", "loadfile", 38, func(c *Client) error {
+			return c.LoadFile("a.mkv", LoadFileModeInsertNext)
+		}},
+		{"LoadFileOptionsInsertNext", "loadfile", 38, func(c *Client) error {
+			return c.LoadFileOptions("a.mkv", LoadFileModeInsertNext, map[string]string{"start": "30"})
+		}},
+		{"LoadFileAppend", "loadfile", 0, func(c *Client) error {
+			return c.LoadFile("a.mkv", LoadFileModeAppend)
+		}},
+		{"SetABLoopCount", "set_property", 36, func(c *Client) error {
+			return c.SetABLoopCount(2)
+		}},
+		{"ABLoopCountProperty", "", 36, func(c *Client) error {
+			_, err := c.GetFloatProperty("ab-loop-count")
+			if errors.Is(err, ErrPropertyUnavailable) {
+				return nil
+			}
+			return err
+		}},
+		{"SetDeinterlaceAuto", "set_property", 38, func(c *Client) error {
+			return c.SetDeinterlace(DeinterlaceAuto)
+		}},
+		{"SetDeinterlaceYes", "set_property", 0, func(c *Client) error {
+			return c.SetDeinterlace(DeinterlaceYes)
+		}},
+	}
+	for _, b := range behaviors {
+		_, minor, ok := parseVersion(b.version)
+		if !ok {
+			t.Fatalf("Can not parse %q", b.version)
+		}
+		for _, tt := range tests {
+			b, tt := b, tt
+			t.Run(b.version+"/"+tt.name, func(t *testing.T) {
+				f := newFakeMPV(t, b)
+				c := NewClient(NewIPCClient(f.ln.Addr().String()))
+				defer c.Close()
+				err := tt.call(c)
+				if minor >= tt.minor {
+					if err != nil {
+						t.Fatalf("Expected success, got %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, ErrUnsupported) {
+					t.Fatalf("Expected ErrUnsupported, got %v", err)
+				}
+				if tt.command != "" && f.sent(tt.command) {
+					t.Errorf("Unsupported %s was sent to mpv", tt.command)
+				}
+			})
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		ok           bool
+	}{
+		{"mpv 0.32.0", 0, 32, true},
+		{"mpv v0.37.0-543-g5a4b1d9", 0, 37, true},
+		{"mpv 0.38.0-dirty", 0, 38, true},
+		{"mpv 1.0", 1, 0, true},
+		{"mpv git-2019-05-04-cc3ba8a", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := parseVersion(tt.version)
+		if major != tt.major || minor != tt.minor || ok != tt.ok {
+			t.Errorf("parseVersion(%q) = %d, %d, %v, expected %d, %d, %v",
+				tt.version, major, minor, ok, tt.major, tt.minor, tt.ok)
+		}
+	}
+}
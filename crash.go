@@ -0,0 +1,167 @@
+package mpv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoCrashDetection is returned by NewCrashMonitor if the lowlevel client
+// can't report a lost connection, see Client.Done.
+var ErrNoCrashDetection = errors.New("Lowlevel client can't detect crashes")
+
+// CrashSnapshot is the playback state of mpv before a crash.
+type CrashSnapshot struct {
+	Path     string  // File playing, "" if idle
+	Position float64 // Playback position in seconds
+	Pause    bool
+}
+
+// CrashError is returned by CrashMonitor.Wait if the connection to mpv was
+// lost without a shutdown event, e.g. because mpv crashed or was killed.
+type CrashError struct {
+	Log      []LogMessageEvent // Last log messages before the crash, oldest first
+	Snapshot CrashSnapshot     // Playback state before the crash
+}
+
+func (e *CrashError) Error() string {
+	msg := "mpv exited without shutting down"
+	if n := len(e.Log); n > 0 {
+		last := e.Log[n-1]
+		msg += ", last message: " + last.Prefix + ": " + strings.TrimSpace(last.Text)
+	}
+	return msg
+}
+
+func (e *CrashError) Unwrap() error {
+	return ErrConnectionLost
+}
+
+// CrashMonitor keeps the last log messages and the playback state of mpv,
+// to report a crash with context and to resume playback after restarting
+// mpv. Log messages and observed properties are restored on reconnect, so
+// the monitor keeps working after Reconnect or a failover.
+// Messages still being dispatched when the connection is lost may be
+// missing from the report, DispatchSync delivers all of them.
+type CrashMonitor struct {
+	client     *Client
+	lines      int
+	ids        []int
+	unregister func()
+
+	mu       sync.Mutex
+	log      []LogMessageEvent
+	snapshot CrashSnapshot
+}
+
+// NewCrashMonitor starts keeping the last n log messages of the given level
+// and more severe ones, e.g. LogLevelWarn. It enables log messages on the
+// client with EnableLogMessages.
+func NewCrashMonitor(c *Client, n int, level LogLevel) (*CrashMonitor, error) {
+	if c.Done() == nil {
+		return nil, ErrNoCrashDetection
+	}
+	m := &CrashMonitor{client: c, lines: n}
+	m.unregister = c.LLClient.RegisterEvent(EventLogMessage, m.logMessage)
+	if err := c.EnableLogMessages(level); err != nil {
+		m.Close()
+		return nil, err
+	}
+	observe := map[string]func(value interface{}){
+		"path": func(value interface{}) {
+			m.mu.Lock()
+			m.snapshot.Path, _ = value.(string)
+			m.mu.Unlock()
+		},
+		"time-pos": func(value interface{}) {
+			if pos, ok := value.(float64); ok {
+				m.mu.Lock()
+				m.snapshot.Position = pos
+				m.mu.Unlock()
+			}
+		},
+		"pause": func(value interface{}) {
+			m.mu.Lock()
+			m.snapshot.Pause, _ = value.(bool)
+			m.mu.Unlock()
+		},
+	}
+	for name, fn := range observe {
+		id, err := c.ObserveProperty(name, fn)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.ids = append(m.ids, id)
+	}
+	return m, nil
+}
+
+func (m *CrashMonitor) logMessage(ev Event) {
+	var msg LogMessageEvent
+	if err := json.Unmarshal(ev.Raw, &msg); err != nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lines <= 0 {
+		return
+	}
+	if len(m.log) == m.lines {
+		m.log = append(m.log[:0], m.log[1:]...)
+	}
+	m.log = append(m.log, msg)
+}
+
+// Wait blocks until the connection to mpv ends. It returns a CrashError if
+// the connection was lost without a shutdown event, nil if mpv shut down or
+// the client was closed, and ctx.Err() if ctx is done first.
+func (m *CrashMonitor) Wait(ctx context.Context) error {
+	select {
+	case <-m.client.Done():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if !errors.Is(m.client.Err(), ErrConnectionLost) {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &CrashError{
+		Log:      append([]LogMessageEvent(nil), m.log...),
+		Snapshot: m.snapshot,
+	}
+}
+
+// Close stops keeping log messages and the playback state. Log messages
+// stay enabled on the client.
+func (m *CrashMonitor) Close() error {
+	if m.unregister != nil {
+		m.unregister()
+	}
+	var firstErr error
+	for _, id := range m.ids {
+		if err := m.client.UnobserveProperty(id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	m.ids = nil
+	return firstErr
+}
+
+// Restore loads the file of a snapshot at its position and pause state,
+// e.g. after restarting mpv and reconnecting following a CrashError.
+// It does nothing if mpv was idle.
+func (c *Client) Restore(s CrashSnapshot) error {
+	if s.Path == "" {
+		return nil
+	}
+	options := map[string]string{"start": strconv.FormatFloat(s.Position, 'f', -1, 64)}
+	if s.Pause {
+		options["pause"] = "yes"
+	}
+	return c.LoadFileOptions(s.Path, LoadFileModeReplace, options)
+}
@@ -0,0 +1,126 @@
+package mpv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// dyingMPV answers every command with success. When end is closed, it sends
+// the given events and closes the connection, with a shutdown event first
+// if shutdown is set.
+func dyingMPV(t *testing.T, events []string, shutdown bool, end <-chan struct{}) string {
+	dir, err := os.MkdirTemp("", "mpv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	ln, err := net.Listen("unix", filepath.Join(dir, "socket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		observed := make(map[string]float64) // Observe ids by property name
+		requests := make(chan []byte)
+		go func() {
+			sc := bufio.NewScanner(conn)
+			for sc.Scan() {
+				requests <- append([]byte(nil), sc.Bytes()...)
+			}
+		}()
+		for {
+			select {
+			case line := <-requests:
+				var req struct {
+					Command   []interface{} `json:"command"`
+					RequestID int           `json:"request_id"`
+				}
+				json.Unmarshal(line, &req)
+				if len(req.Command) == 3 && req.Command[0] == "observe_property" {
+					observed[req.Command[2].(string)] = req.Command[1].(float64)
+				}
+				fmt.Fprintf(conn, `{"request_id":%d,"error":"success"}`+"\n", req.RequestID)
+			case <-end:
+				fmt.Fprintf(conn, `{"event":"property-change","id":%v,"name":"path","data":"movie.mkv"}`+"\n", observed["path"])
+				fmt.Fprintf(conn, `{"event":"property-change","id":%v,"name":"time-pos","data":42.5}`+"\n", observed["time-pos"])
+				for _, ev := range events {
+					fmt.Fprintln(conn, ev)
+				}
+				if shutdown {
+					fmt.Fprintln(conn, `{"event":"shutdown"}`)
+				}
+				return
+			}
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func logLine(text string) string {
+	return fmt.Sprintf(`{"event":"log-message","prefix":"cplayer","level":"error","text":%q}`, text)
+}
+
+func TestCrashMonitorReportsCrash(t *testing.T) {
+	end := make(chan struct{})
+	socket := dyingMPV(t, []string{logLine("one\n"), logLine("two\n"), logLine("three\n")}, false, end)
+	c := NewClient(NewIPCClient(socket, WithDispatchMode(DispatchSync)))
+	defer c.Close()
+	m, err := NewCrashMonitor(c, 2, LogLevelWarn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	close(end)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = m.Wait(ctx)
+	var crash *CrashError
+	if !errors.As(err, &crash) {
+		t.Fatalf("Expected a CrashError, got %v", err)
+	}
+	if !errors.Is(err, ErrConnectionLost) {
+		t.Errorf("Expected the CrashError to match ErrConnectionLost")
+	}
+	if len(crash.Log) != 2 || crash.Log[0].Text != "two\n" || crash.Log[1].Text != "three\n" {
+		t.Errorf("Expected the last 2 log messages, got %+v", crash.Log)
+	}
+	if crash.Snapshot.Path != "movie.mkv" || crash.Snapshot.Position != 42.5 {
+		t.Errorf("Unexpected snapshot %+v", crash.Snapshot)
+	}
+}
+
+func TestCrashMonitorIgnoresShutdown(t *testing.T) {
+	end := make(chan struct{})
+	socket := dyingMPV(t, []string{logLine("bye\n")}, true, end)
+	c := NewClient(NewIPCClient(socket, WithDispatchMode(DispatchSync)))
+	defer c.Close()
+	m, err := NewCrashMonitor(c, 2, LogLevelWarn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	close(end)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Wait(ctx); err != nil {
+		t.Fatalf("Expected no error after a shutdown, got %v", err)
+	}
+	if !errors.Is(c.Err(), ErrShutdown) {
+		t.Errorf("Expected ErrShutdown, got %v", c.Err())
+	}
+}
@@ -0,0 +1,102 @@
+package mpv
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LatencyStats summarizes latency samples.
+type LatencyStats struct {
+	Samples int
+	Min     time.Duration
+	Median  time.Duration
+	P95     time.Duration
+	Max     time.Duration
+}
+
+func newLatencyStats(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return LatencyStats{
+		Samples: len(sorted),
+		Min:     sorted[0],
+		Median:  sorted[len(sorted)/2],
+		P95:     sorted[(len(sorted)*95)/100],
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// DiagnosticsReport holds the latencies measured by Client.Diagnostics.
+type DiagnosticsReport struct {
+	// CommandRoundTrip is the time from sending a command until its response arrives.
+	CommandRoundTrip LatencyStats
+	// EventDelivery is the time from sending a property change until the
+	// corresponding property-change event arrives.
+	EventDelivery LatencyStats
+}
+
+// diagnosticsProperty is echoed back by mpv to measure event delivery.
+const diagnosticsProperty = "user-data/mpv-go-diagnostics"
+
+// Diagnostics measures n command round trips and n event deliveries, to tell
+// slow IPC apart from a slow player. Event delivery is measured by observing a
+// user-data property and changing it, which requires mpv 0.36 or newer.
+// It returns an error wrapping ErrInvalidValue if n is not positive.
+// Latencies are measured with the clock of the lowlevel client.
+func (c *Client) Diagnostics(n int) (*DiagnosticsReport, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("diagnostics samples %d: %w", n, ErrInvalidValue)
+	}
+	clock := c.clock()
+	report := &DiagnosticsReport{}
+
+	samples := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		start := clock.Now()
+		if _, err := c.exec("get_time_us"); err != nil {
+			return nil, err
+		}
+		samples = append(samples, clock.Now().Sub(start))
+	}
+	report.CommandRoundTrip = newLatencyStats(samples)
+
+	echo := make(chan float64, n+2)
+	id, err := c.ObserveProperty(diagnosticsProperty, func(value interface{}) {
+		if v, ok := value.(float64); ok {
+			select {
+			case echo <- v:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer c.UnobserveProperty(id)
+
+	samples = samples[:0]
+	for i := 1; i <= n; i++ {
+		start := clock.Now()
+		if _, err := c.exec("set_property", diagnosticsProperty, i); err != nil {
+			return nil, err
+		}
+	wait:
+		for {
+			select {
+			case v := <-echo:
+				if v == float64(i) {
+					break wait
+				}
+			case <-clock.After(2 * time.Second):
+				return nil, ErrTimeoutRecv
+			}
+		}
+		samples = append(samples, clock.Now().Sub(start))
+	}
+	report.EventDelivery = newLatencyStats(samples)
+	return report, nil
+}
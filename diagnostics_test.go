@@ -0,0 +1,15 @@
+package mpv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiagnosticsInvalidSamples(t *testing.T) {
+	c := NewClient(newStubClient())
+	for _, n := range []int{0, -1} {
+		if _, err := c.Diagnostics(n); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("Diagnostics(%d): expected ErrInvalidValue, got %v", n, err)
+		}
+	}
+}
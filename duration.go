@@ -0,0 +1,50 @@
+package mpv
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// seconds converts mpv's float seconds to a time.Duration, rounded to the
+// nearest nanosecond instead of truncated.
+func seconds(f float64) time.Duration {
+	return time.Duration(math.Round(f * float64(time.Second)))
+}
+
+// PositionDuration returns the playback position.
+func (c *Client) PositionDuration() (time.Duration, error) {
+	f, err := c.GetFloatProperty("time-pos")
+	return seconds(f), err
+}
+
+// DurationDuration returns the duration of the current file.
+func (c *Client) DurationDuration() (time.Duration, error) {
+	f, err := c.GetFloatProperty("duration")
+	return seconds(f), err
+}
+
+// TimeRemainingDuration returns the remaining playback time of the current file.
+func (c *Client) TimeRemainingDuration() (time.Duration, error) {
+	f, err := c.GetFloatProperty("time-remaining")
+	return seconds(f), err
+}
+
+// SeekTo seeks by d relative to the current position (SeekModeRelative) or
+// to d (SeekModeAbsolute). An empty mode is relative.
+func (c *Client) SeekTo(d time.Duration, mode string) error {
+	return c.Seek(d.Seconds(), SeekOptions{Mode: mode})
+}
+
+// LoadFileAt loads a file like LoadFile and plays it from start to end, e.g.
+// to resume at a bookmark or to play an excerpt. An end of 0 plays to the
+// end of the file.
+func (c *Client) LoadFileAt(path string, start, end time.Duration, mode string) error {
+	options := map[string]string{
+		"start": strconv.FormatFloat(start.Seconds(), 'f', -1, 64),
+	}
+	if end > 0 {
+		options["end"] = strconv.FormatFloat(end.Seconds(), 'f', -1, 64)
+	}
+	return c.LoadFileOptions(path, mode, options)
+}
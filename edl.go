@@ -0,0 +1,8 @@
+package mpv
+
+// LoadEDL loads an EDL as a single file, like LoadFile with the same modes.
+// e is usually an *edl.EDL of the edl package. The package is not imported
+// here, so this package builds without its subpackages.
+func (c *Client) LoadEDL(e interface{ URL() string }, mode string) error {
+	return c.LoadFile(e.URL(), mode)
+}
@@ -0,0 +1,92 @@
+// Package edl builds mpv EDL (edit decision list) playlists, which play
+// parts of one or more files as a single virtual file.
+package edl
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// header starts an EDL file.
+const header = "# mpv EDL v0\n"
+
+// Segment is a part of a source file.
+type Segment struct {
+	Source string        // Path or URL of the file
+	Start  time.Duration // Start within the source
+	Length time.Duration // Length of the segment, 0 plays until the end of the source
+	Title  string        // Chapter title of the segment, optional
+}
+
+// EDL is a list of segments played one after another.
+type EDL struct {
+	Segments []Segment
+}
+
+// New returns an EDL playing the segments.
+func New(segments ...Segment) *EDL {
+	return &EDL{Segments: segments}
+}
+
+// Add appends a segment of source starting at start with the given length.
+func (e *EDL) Add(source string, start, length time.Duration) *EDL {
+	e.Segments = append(e.Segments, Segment{Source: source, Start: start, Length: length})
+	return e
+}
+
+// AddTitled is like Add and sets the chapter title of the segment.
+func (e *EDL) AddTitled(title, source string, start, length time.Duration) *EDL {
+	e.Segments = append(e.Segments, Segment{Source: source, Start: start, Length: length, Title: title})
+	return e
+}
+
+// URL returns the EDL as an edl:// URL, which can be loaded like a file
+// without writing it to disk.
+func (e *EDL) URL() string {
+	lines := make([]string, len(e.Segments))
+	for i, s := range e.Segments {
+		lines[i] = s.String()
+	}
+	return "edl://" + strings.Join(lines, ";")
+}
+
+// WriteTo writes the EDL in the file format, to be saved with the .edl extension.
+func (e *EDL) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	b.WriteString(header)
+	for _, s := range e.Segments {
+		b.WriteString(s.String())
+		b.WriteByte('\n')
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// String returns the segment in EDL syntax.
+func (s Segment) String() string {
+	parts := []string{escape(s.Source)}
+	if s.Start != 0 || s.Length != 0 {
+		parts = append(parts, "start="+seconds(s.Start))
+	}
+	if s.Length != 0 {
+		parts = append(parts, "length="+seconds(s.Length))
+	}
+	if s.Title != "" {
+		parts = append(parts, "title="+escape(s.Title))
+	}
+	return strings.Join(parts, ",")
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// escape quotes values containing EDL syntax with the %length% prefix.
+func escape(v string) string {
+	if strings.ContainsAny(v, ",;=%\n\r") || strings.TrimSpace(v) != v {
+		return "%" + strconv.Itoa(len(v)) + "%" + v
+	}
+	return v
+}
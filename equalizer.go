@@ -0,0 +1,55 @@
+package mpv
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EqualizerBands is the number of bands of an Equalizer. The bands are
+// centered at 65, 92, 131, 185, 262, 370, 523, 740, 1047, 1480, 2093,
+// 2960, 4186, 5920, 8372, 11840, 16744 and 20000 Hz.
+const EqualizerBands = 18
+
+// Equalizer holds the gains of a graphic equalizer in dB, from the lowest
+// band to the highest. Missing bands are left at 0 dB, extra bands are
+// ignored. Gains above 26 dB are limited to 26 dB.
+type Equalizer []float64
+
+// Equalizer presets
+var (
+	EqualizerFlat      = Equalizer{}
+	EqualizerBassBoost = Equalizer{6, 6, 5, 4, 3, 1.5}
+	EqualizerVocal     = Equalizer{-2, -2, -2, -1, 0, 1, 3, 4, 4, 3, 2, 1, 0, -1, -2, -2, -2, -2}
+)
+
+// equalizerLabel identifies the filter of SetEqualizer in the af chain.
+const equalizerLabel = "@mpv-go-equalizer"
+
+// Filter returns the audio filter implementing the equalizer with ffmpeg's
+// superequalizer.
+func (e Equalizer) Filter() string {
+	params := make([]string, EqualizerBands)
+	for i := range params {
+		var db float64
+		if i < len(e) {
+			db = e[i]
+		}
+		gain := math.Pow(10, db/20) // superequalizer takes linear gains in 0..20
+		if gain > 20 {
+			gain = 20
+		}
+		params[i] = strconv.Itoa(i+1) + "b=" + strconv.FormatFloat(gain, 'f', 4, 64)
+	}
+	return "lavfi-superequalizer=" + strings.Join(params, ":")
+}
+
+// SetEqualizer applies the equalizer, replacing one set before.
+func (c *Client) SetEqualizer(e Equalizer) error {
+	return c.command("af", "add", equalizerLabel+":"+e.Filter())
+}
+
+// ClearEqualizer removes the equalizer set by SetEqualizer.
+func (c *Client) ClearEqualizer() error {
+	return c.command("af", "remove", equalizerLabel)
+}
@@ -0,0 +1,216 @@
+package mpv
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// EndFileReason is the reason of an end-file event.
+type EndFileReason int
+
+// End-file reasons
+const (
+	EndFileUnknown  EndFileReason = iota // Unknown reason
+	EndFileEOF                           // File ended
+	EndFileStop                          // Stopped by a command, e.g. loading another file
+	EndFileQuit                          // Player quit
+	EndFileError                         // File could not be played
+	EndFileRedirect                      // File was a playlist or similar redirect
+)
+
+var endFileReasons = []string{"unknown", "eof", "stop", "quit", "error", "redirect"}
+
+// ParseEndFileReason parses the reason string sent by mpv. Unknown strings
+// result in EndFileUnknown.
+func ParseEndFileReason(s string) EndFileReason {
+	for i, name := range endFileReasons {
+		if name == s {
+			return EndFileReason(i)
+		}
+	}
+	return EndFileUnknown
+}
+
+func (r EndFileReason) String() string {
+	if r < 0 || int(r) >= len(endFileReasons) {
+		return endFileReasons[EndFileUnknown]
+	}
+	return endFileReasons[r]
+}
+
+// UnmarshalJSON decodes the reason string sent by mpv.
+func (r *EndFileReason) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*r = ParseEndFileReason(s)
+	return nil
+}
+
+// EndFileEvent is the payload of EventEndFile.
+type EndFileEvent struct {
+	Reason          EndFileReason `json:"reason"`
+	Error           string        `json:"file_error"` // Set if Reason is EndFileError
+	PlaylistEntryID int           `json:"playlist_entry_id"`
+}
+
+// StartFileEvent is the payload of EventStartFile.
+type StartFileEvent struct {
+	PlaylistEntryID int `json:"playlist_entry_id"`
+}
+
+// LogMessageEvent is the payload of EventLogMessage.
+type LogMessageEvent struct {
+	Prefix string   `json:"prefix"` // Module which logged the message
+	Level  LogLevel `json:"level"`
+	Text   string   `json:"text"`
+}
+
+// ClientMessageEvent is the payload of EventClientMessage, e.g. sent by script-message.
+type ClientMessageEvent struct {
+	Args []string `json:"args"`
+}
+
+// PropertyChangeEvent is the payload of EventPropertyChange.
+type PropertyChangeEvent struct {
+	ID   int         `json:"id"` // Observe id
+	Name string      `json:"name"`
+	Data interface{} `json:"data"` // nil if the property is unavailable
+}
+
+// Decode decodes the payload of the event into its typed struct, e.g. an
+// EndFileEvent for EventEndFile. Events without a typed payload are
+// returned unchanged.
+func (e Event) Decode() (interface{}, error) {
+	switch e.Name {
+	case EventEndFile:
+		var v EndFileEvent
+		err := json.Unmarshal(e.Raw, &v)
+		return v, err
+	case EventStartFile:
+		var v StartFileEvent
+		err := json.Unmarshal(e.Raw, &v)
+		return v, err
+	case EventLogMessage:
+		var v LogMessageEvent
+		err := json.Unmarshal(e.Raw, &v)
+		return v, err
+	case EventClientMessage:
+		var v ClientMessageEvent
+		err := json.Unmarshal(e.Raw, &v)
+		return v, err
+	case EventPropertyChange:
+		var v PropertyChangeEvent
+		err := json.Unmarshal(e.Raw, &v)
+		return v, err
+	}
+	return e, nil
+}
+
+// Events returns a channel receiving the named events and a function to
+// cancel the subscription, which closes the channel. The channel is
+// buffered, a consumer not keeping up delays further dispatch of the events.
+func (c *Client) Events(names ...string) (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+	done := make(chan struct{})
+	var (
+		mu     sync.RWMutex
+		closed bool
+		once   sync.Once
+	)
+	send := func(ev Event) {
+		mu.RLock()
+		defer mu.RUnlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- ev:
+		case <-done:
+		}
+	}
+	unregister := make([]func(), 0, len(names))
+	for _, name := range names {
+		unregister = append(unregister, c.LLClient.RegisterEvent(name, send))
+	}
+	cancel := func() {
+		once.Do(func() {
+			for _, fn := range unregister {
+				fn()
+			}
+			close(done) // Unblock pending sends before closing ch
+			mu.Lock()
+			closed = true
+			close(ch)
+			mu.Unlock()
+		})
+	}
+	return ch, cancel
+}
+
+// WaitForEvent blocks until the named event is received or ctx is done.
+// Events dispatched before the call are not seen, use WaitForEventAfter to
+// wait for the result of a command.
+func (c *Client) WaitForEvent(ctx context.Context, name string) (Event, error) {
+	return c.WaitForEventAfter(ctx, name, nil)
+}
+
+// WaitForEventAfter subscribes to the named event, then calls run and
+// blocks until the event is received or ctx is done. As the subscription
+// comes first, the event can't be missed if run triggers it quickly:
+//
+//	ev, err := c.WaitForEventAfter(ctx, mpv.EventFileLoaded, func() error {
+//		return c.LoadFile(path, mpv.LoadFileModeReplace)
+//	})
+//
+// If run returns an error, it is returned without waiting. run may be nil.
+func (c *Client) WaitForEventAfter(ctx context.Context, name string, run func() error) (Event, error) {
+	ch := make(chan Event, 1)
+	unregister := c.LLClient.RegisterEvent(name, func(ev Event) {
+		select {
+		case ch <- ev:
+		default:
+		}
+	})
+	defer unregister()
+	if run != nil {
+		if err := run(); err != nil {
+			return Event{}, err
+		}
+	}
+	select {
+	case ev := <-ch:
+		return ev, nil
+	case <-ctx.Done():
+		return Event{}, ctx.Err()
+	}
+}
+
+// WaitForProperty observes the property until predicate returns true for its
+// value or ctx is done, and returns that value. The predicate is checked
+// against the current value first, so it returns immediately if the
+// property already matches.
+func (c *Client) WaitForProperty(ctx context.Context, name string, predicate func(value interface{}) bool) (interface{}, error) {
+	ch := make(chan interface{}, 1)
+	id, err := c.ObserveProperty(name, func(value interface{}) {
+		if !predicate(value) {
+			return
+		}
+		select {
+		case ch <- value:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer c.UnobserveProperty(id)
+	select {
+	case v := <-ch:
+		return v, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,76 @@
+// Command player is a minimal terminal music player. It shows the current
+// title and position and reads single letter commands from stdin.
+//
+//	mpv --idle --no-video --input-ipc-server=/tmp/mpvsocket
+//	player -socket /tmp/mpvsocket song1.mp3 song2.mp3
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blang/mpv"
+)
+
+const help = "commands: p pause, n next, b back, m mute, + louder, - quieter, q quit"
+
+func main() {
+	socket := flag.String("socket", "/tmp/mpvsocket", "mpv IPC socket")
+	flag.Parse()
+
+	c := mpv.NewClient(mpv.NewIPCClient(*socket))
+	defer c.Close()
+
+	for _, f := range flag.Args() {
+		if err := c.LoadFile(f, mpv.LoadFileModeAppendPlay); err != nil {
+			log.Printf("load %s: %v", f, err)
+		}
+	}
+
+	c.RegisterEvent(mpv.EventFileLoaded, func(mpv.Event) {
+		fmt.Printf("\nNow playing: %s (%s)\n", c.MediaTitle(), mpv.FormatBitrate(c.AudioBitrate()))
+	})
+	c.ObserveProperty("time-pos", func(value interface{}) {
+		pos, ok := value.(float64)
+		if !ok {
+			return
+		}
+		fmt.Printf("\r%s / %s ", format(pos), format(c.Duration()))
+	})
+
+	fmt.Println(help)
+	in := bufio.NewScanner(os.Stdin)
+	for in.Scan() {
+		var err error
+		switch strings.TrimSpace(in.Text()) {
+		case "p":
+			err = c.Pause()
+		case "n":
+			err = c.PlayNext()
+		case "b":
+			err = c.PlayPrev()
+		case "m":
+			err = c.Mute()
+		case "+":
+			err = c.Volume(c.CurrentVolume() + 5)
+		case "-":
+			err = c.Volume(c.CurrentVolume() - 5)
+		case "q":
+			return
+		default:
+			fmt.Println(help)
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func format(seconds float64) string {
+	return mpv.FormatDuration(time.Duration(seconds * float64(time.Second)))
+}
@@ -0,0 +1,72 @@
+// Command remote serves a small HTTP remote control for a running mpv.
+//
+//	mpv --idle --input-ipc-server=/tmp/mpvsocket
+//	remote -socket /tmp/mpvsocket -addr :8080
+//
+// POST /mpv accepts lowlevel json commands, the other routes map to
+// highlevel client methods, e.g. POST /pause or POST /load?path=movie.mp4.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/blang/mpv"
+)
+
+func main() {
+	socket := flag.String("socket", "/tmp/mpvsocket", "mpv IPC socket")
+	addr := flag.String("addr", ":8080", "HTTP listen address")
+	flag.Parse()
+
+	ipcc := mpv.NewIPCClient(*socket)
+	c := mpv.NewClient(ipcc)
+	c.SetDefaultOSD(mpv.OSDMsgBar) // Show feedback on the player's screen
+
+	http.Handle("/mpv", mpv.HTTPServerHandler(ipcc))
+	http.HandleFunc("/pause", action(c.Pause))
+	http.HandleFunc("/next", action(c.PlayNext))
+	http.HandleFunc("/prev", action(c.PlayPrev))
+	http.HandleFunc("/mute", action(c.Mute))
+	http.HandleFunc("/load", func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Query().Get("path")
+		action(func() error {
+			return c.LoadFile(path, mpv.LoadFileModeReplace)
+		})(w, r)
+	})
+	http.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		status := map[string]interface{}{
+			"file":     c.CurrentFile(),
+			"title":    c.MediaTitle(),
+			"paused":   c.IsPause(),
+			"position": mpv.FormatDuration(secondsToDuration(c.Position())),
+			"duration": mpv.FormatDuration(secondsToDuration(c.Duration())),
+			"volume":   c.CurrentVolume(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// action adapts a client method to a POST handler.
+func action(fn func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := fn(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,64 @@
+// Command signage plays a list of files in an endless loop on a running
+// mpv and keeps it going unattended: a watchdog reconnects if mpv stops
+// answering, and the playlist is reloaded after a reconnect.
+//
+//	mpv --idle --fullscreen --input-ipc-server=/tmp/mpvsocket
+//	signage -socket /tmp/mpvsocket intro.mp4 offers.png outro.mp4
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/blang/mpv"
+)
+
+func main() {
+	socket := flag.String("socket", "/tmp/mpvsocket", "mpv IPC socket")
+	flag.Parse()
+	files := flag.Args()
+	if len(files) == 0 {
+		log.Fatal("no files given")
+	}
+
+	ipcc := mpv.NewIPCClient(*socket)
+	c := mpv.NewClient(ipcc)
+	c.SetDefaultOSD(mpv.OSDNone)
+
+	start := func() {
+		for i, f := range files {
+			mode := mpv.LoadFileModeAppend
+			if i == 0 {
+				mode = mpv.LoadFileModeReplace
+			}
+			if err := c.LoadFile(f, mode); err != nil {
+				log.Printf("load %s: %v", f, err)
+			}
+		}
+		if err := c.PlayLoop(); err != nil {
+			log.Printf("loop: %v", err)
+		}
+	}
+
+	c.RegisterEvent(mpv.EventEndFile, func(ev mpv.Event) {
+		v, err := ev.Decode()
+		if err != nil {
+			return
+		}
+		if end := v.(mpv.EndFileEvent); end.Reason == mpv.EndFileError {
+			log.Printf("playback failed: %s", end.Error)
+		}
+	})
+	c.RegisterEvent(mpv.EventReconnected, func(mpv.Event) {
+		log.Print("reconnected, restarting playlist")
+		start()
+	})
+
+	w := mpv.NewWatchdog(ipcc, 5*time.Second, 3)
+	w.Start()
+	defer w.Stop()
+
+	start()
+	select {}
+}
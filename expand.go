@@ -0,0 +1,24 @@
+package mpv
+
+// ExpandText expands property references in text like mpv does for
+// show-text and the OSD, e.g. "${media-title} - ${time-pos}".
+func (c *Client) ExpandText(text string) (string, error) {
+	return c.expand("expand-text", text)
+}
+
+// ExpandPath expands mpv's path prefixes like "~~/" (the config directory)
+// and "~/" (the home directory).
+func (c *Client) ExpandPath(path string) (string, error) {
+	return c.expand("expand-path", path)
+}
+
+func (c *Client) expand(command, s string) (string, error) {
+	res, err := c.exec(command, s)
+	if err != nil {
+		return "", err
+	}
+	if v, ok := res.Data.(string); ok {
+		return v, nil
+	}
+	return "", ErrInvalidType
+}
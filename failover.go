@@ -0,0 +1,153 @@
+package mpv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// EventBackendChanged is not sent by mpv, it is dispatched by FailoverClient
+// after switching to another socket. The event data holds the socket path.
+const EventBackendChanged = "backend-changed"
+
+// ErrNoBackend is returned if none of the sockets of a FailoverClient accepts a connection.
+var ErrNoBackend = errors.New("No backend available")
+
+var _ LLClient = (*FailoverClient)(nil)
+
+// FailoverClient is a low-level client connected to one of several mpv
+// instances. If the connection to the active instance fails, it switches to
+// the next socket in order and dispatches EventBackendChanged. Registered
+// event handlers are kept across switches.
+// Commands sent while switching fail with ErrClosed.
+type FailoverClient struct {
+	ipc     *IPCClient
+	sockets []string
+
+	mu     sync.Mutex
+	active int
+	closed bool
+}
+
+// NewFailoverClient creates a FailoverClient connected to the first
+// reachable socket of the given ordered list.
+func NewFailoverClient(sockets []string, opts ...IPCOption) (*FailoverClient, error) {
+	if len(sockets) == 0 {
+		return nil, ErrNoBackend
+	}
+	f := &FailoverClient{
+		ipc:     newIPCClient(sockets[0], opts),
+		sockets: sockets,
+		active:  len(sockets) - 1, // Start the search with the first socket
+	}
+	if !f.failover() {
+		return nil, ErrNoBackend
+	}
+	go f.watch()
+	return f, nil
+}
+
+// Active returns the socket of the active mpv instance.
+func (f *FailoverClient) Active() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sockets[f.active]
+}
+
+// Exec executes a command on the active mpv instance.
+func (f *FailoverClient) Exec(command ...interface{}) (*Response, error) {
+	return f.ipc.Exec(command...)
+}
+
+// Done returns a channel which is closed when the active mpv instance shuts
+// down, the connection to it is lost or the client is closed, see
+// IPCClient.Done. After failing over, Done returns a new channel.
+func (f *FailoverClient) Done() <-chan struct{} {
+	return f.ipc.Done()
+}
+
+// Err returns why Done was closed, see IPCClient.Err.
+func (f *FailoverClient) Err() error {
+	return f.ipc.Err()
+}
+
+// Clock returns the clock of the client, see IPCClient.Clock.
+func (f *FailoverClient) Clock() Clock {
+	return f.ipc.Clock()
+}
+
+func (f *FailoverClient) callHandler(fn func(Event), ev Event) {
+	f.ipc.callHandler(fn, ev)
+}
+
+// ExecContext executes a command asynchronously on the active mpv instance,
+// see IPCClient.ExecContext.
+func (f *FailoverClient) ExecContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	return f.ipc.ExecContext(ctx, command...)
+}
+
+// RegisterEvent registers an event handler, see IPCClient.RegisterEvent.
+func (f *FailoverClient) RegisterEvent(name string, handle func(Event)) func() {
+	return f.ipc.RegisterEvent(name, handle)
+}
+
+// Close closes the connection to the active mpv instance and stops failing over.
+func (f *FailoverClient) Close() error {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+	return f.ipc.Close()
+}
+
+func (f *FailoverClient) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+// UnregisterEvent removes an event handler, see IPCClient.UnregisterEvent.
+func (f *FailoverClient) UnregisterEvent(name string) {
+	f.ipc.UnregisterEvent(name)
+}
+
+// watch fails over whenever the active connection fails.
+func (f *FailoverClient) watch() {
+	for {
+		<-f.ipc.connectionLost()
+		for {
+			if f.isClosed() {
+				return
+			}
+			if f.failover() {
+				break
+			}
+			f.ipc.clock.Sleep(time.Second)
+		}
+	}
+}
+
+// failover connects to the first reachable socket after the active one,
+// trying the active socket last. It reports whether a connection was made.
+func (f *FailoverClient) failover() bool {
+	f.mu.Lock()
+	active := f.active
+	f.mu.Unlock()
+	for i := 1; i <= len(f.sockets); i++ {
+		next := (active + i) % len(f.sockets)
+		if err := f.ipc.connect(f.sockets[next]); err != nil {
+			continue
+		}
+		f.mu.Lock()
+		if f.closed { // Closed while connecting
+			f.mu.Unlock()
+			f.ipc.Close()
+			return true
+		}
+		f.active = next
+		f.mu.Unlock()
+		f.ipc.dispatchEvent(Event{Name: EventBackendChanged, Data: f.sockets[next]})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package mpv
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Locale holds the locale dependent parts of the Format functions.
+type Locale struct {
+	DecimalSeparator string
+	SizeUnits        []string // Units for powers of 1024 bytes, starting with bytes
+	BitrateUnits     []string // Units for powers of 1000 bits/s, starting with bits/s
+}
+
+// DefaultLocale is used by the package level Format functions.
+var DefaultLocale = Locale{
+	DecimalSeparator: ".",
+	SizeUnits:        []string{"B", "KiB", "MiB", "GiB", "TiB"},
+	BitrateUnits:     []string{"bps", "kbps", "Mbps", "Gbps"},
+}
+
+// FormatDuration formats d as h:mm:ss, or m:ss if shorter than an hour,
+// like the mpv OSD does. Fractions of a second are truncated.
+func (l Locale) FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	s := int64(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, s/60, s%60)
+}
+
+// FormatSize formats a size in bytes, e.g. "1.5 MiB".
+func (l Locale) FormatSize(bytes int) string {
+	return l.scaled(float64(bytes), 1024, l.SizeUnits)
+}
+
+// FormatBitrate formats a bitrate in bits per second, e.g. "320 kbps",
+// as returned by AudioBitrate and VideoBitrate.
+func (l Locale) FormatBitrate(bps int) string {
+	return l.scaled(float64(bps), 1000, l.BitrateUnits)
+}
+
+// scaled formats v in the largest unit keeping it at or above 1. The unit
+// is picked after rounding, so 1048575 bytes are "1 MiB", not "1024 KiB".
+func (l Locale) scaled(v float64, base float64, units []string) string {
+	i := 0
+	for i < len(units)-1 && math.Abs(roundScaled(v, i)) >= base {
+		v /= base
+		i++
+	}
+	var s string
+	if i == 0 {
+		s = fmt.Sprintf("%.0f", v)
+	} else {
+		s = strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0")
+		s = strings.Replace(s, ".", l.DecimalSeparator, 1)
+	}
+	if len(units) == 0 {
+		return s
+	}
+	return s + " " + units[i]
+}
+
+// roundScaled rounds v like scaled prints it in the unit with index i.
+func roundScaled(v float64, i int) float64 {
+	if i == 0 {
+		return math.Round(v)
+	}
+	return math.Round(v*10) / 10
+}
+
+// FormatDuration formats d using DefaultLocale.
+func FormatDuration(d time.Duration) string {
+	return DefaultLocale.FormatDuration(d)
+}
+
+// FormatSize formats a size in bytes using DefaultLocale.
+func FormatSize(bytes int) string {
+	return DefaultLocale.FormatSize(bytes)
+}
+
+// FormatBitrate formats a bitrate in bits per second using DefaultLocale.
+func FormatBitrate(bps int) string {
+	return DefaultLocale.FormatBitrate(bps)
+}
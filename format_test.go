@@ -0,0 +1,62 @@
+package mpv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes int
+		want  string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{1048575, "1 MiB"}, // Rounds up to the next unit
+		{1048576, "1 MiB"},
+		{1073689395, "1023.9 MiB"},
+		{-1536, "-1.5 KiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatSize(tt.bytes); got != tt.want {
+			t.Errorf("FormatSize(%d) = %q, expected %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBitrate(t *testing.T) {
+	tests := []struct {
+		bps  int
+		want string
+	}{
+		{999, "999 bps"},
+		{320000, "320 kbps"},
+		{999960, "1 Mbps"}, // Rounds up to the next unit
+		{1500000, "1.5 Mbps"},
+	}
+	for _, tt := range tests {
+		if got := FormatBitrate(tt.bps); got != tt.want {
+			t.Errorf("FormatBitrate(%d) = %q, expected %q", tt.bps, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0:00"},
+		{59*time.Second + 999*time.Millisecond, "0:59"},
+		{61 * time.Second, "1:01"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+		{-90 * time.Second, "-1:30"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, expected %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/blang/mpv
+
+go 1.16
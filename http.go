@@ -1,7 +1,9 @@
 package mpv
 
-import "encoding/json"
-import "net/http"
+import (
+	"io"
+	"net/http"
+)
 
 // JSONRequest send to the server.
 type JSONRequest struct {
@@ -16,6 +18,7 @@ type JSONResponse struct {
 
 type httpServerHandler struct {
 	llclient LLClient
+	codec    Codec
 }
 
 // HTTPServerHandler returns a http.Handler to access a client via a lowlevel json-api.
@@ -28,8 +31,15 @@ type httpServerHandler struct {
 // Result:
 // 		{"error":"success","data":false}
 func HTTPServerHandler(client LLClient) http.Handler {
+	return HTTPServerHandlerCodec(client, JSONCodec)
+}
+
+// HTTPServerHandlerCodec returns a http.Handler like HTTPServerHandler which
+// decodes requests and encodes responses with the given codec.
+func HTTPServerHandlerCodec(client LLClient, codec Codec) http.Handler {
 	return &httpServerHandler{
 		llclient: client,
+		codec:    codec,
 	}
 }
 
@@ -39,8 +49,10 @@ func (h *httpServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req JSONRequest
-	dec := json.NewDecoder(r.Body)
-	err := dec.Decode(&req)
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = h.codec.Unmarshal(body, &req)
+	}
 	if err != nil {
 		http.Error(w, "Can not decode request", http.StatusBadRequest)
 		return
@@ -59,11 +71,12 @@ func (h *httpServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Err:  resp.Err,
 		Data: resp.Data,
 	}
-	b, err := json.Marshal(jsonResp)
+	b, err := h.codec.Marshal(jsonResp)
 	if err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", h.codec.ContentType())
 	_, err = w.Write(b)
 	if err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
@@ -0,0 +1,20 @@
+package mpv
+
+// KeyPress sends a key press to mpv's input handling, so it triggers the
+// key's binding like a real key press, e.g. "SPACE" or "ctrl+RIGHT".
+func (c *Client) KeyPress(key string) error {
+	return c.command("keypress", key)
+}
+
+// KeyDown presses a key and holds it until KeyUp is called.
+func (c *Client) KeyDown(key string) error {
+	return c.command("keydown", key)
+}
+
+// KeyUp releases a key pressed with KeyDown. An empty key releases all keys.
+func (c *Client) KeyUp(key string) error {
+	if key == "" {
+		return c.command("keyup")
+	}
+	return c.command("keyup", key)
+}
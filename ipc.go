@@ -2,12 +2,17 @@ package mpv
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
-	"math/rand"
+	"log"
 	"net"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,6 +33,13 @@ const (
 	EventPropertyChange  = "property-change"
 )
 
+// EventAll registers a handler for all events, see RegisterEvent.
+const EventAll = "*"
+
+// EventReconnected is not sent by mpv, it is dispatched by the client after
+// the connection to mpv was reestablished, see IPCClient.Reconnect.
+const EventReconnected = "reconnected"
+
 var _ LLClient = (*IPCClient)(nil)
 
 // Response received from mpv. Can be an event or a user requested response.
@@ -38,17 +50,37 @@ type Response struct {
 	RequestID int         `json:"request_id"`
 }
 
+// Event is an event sent by mpv.
+type Event struct {
+	Name string      // Name of the event, e.g. EventEndFile
+	Data interface{} // Set for property-change events
+	// Raw is the complete event object as received from mpv, to access event
+	// specific fields like the reason of end-file or the args of client-message.
+	Raw json.RawMessage
+}
+
 // request sent to mpv. Includes request_id for mapping the response.
 type request struct {
-	Command   []interface{}  `json:"command"`
+	Command   interface{}    `json:"command"` // Argument list or named arguments
 	RequestID int            `json:"request_id"`
+	Async     bool           `json:"async,omitempty"` // Run without blocking mpv's other commands
 	Response  chan *Response `json:"-"`
 }
 
+// requestCount allocates request ids. Random ids could collide between
+// concurrent requests.
+var requestCount int64
+
 func newRequest(cmd ...interface{}) *request {
+	var command interface{} = cmd
+	if len(cmd) == 1 {
+		if named, ok := cmd[0].(map[string]interface{}); ok {
+			command = named
+		}
+	}
 	return &request{
-		Command:   cmd,
-		RequestID: rand.Intn(10000),
+		Command:   command,
+		RequestID: int(atomic.AddInt64(&requestCount, 1)),
 		Response:  make(chan *Response, 1),
 	}
 }
@@ -56,37 +88,159 @@ func newRequest(cmd ...interface{}) *request {
 // LLClient is the most low level interface
 type LLClient interface {
 	Exec(command ...interface{}) (*Response, error)
-	RegisterEvent(name string, handle func())
+	RegisterEvent(name string, handle func(Event)) (unregister func())
+	UnregisterEvent(name string)
 }
 
 // IPCClient is a low-level IPC client to communicate with the mpv player via socket.
 type IPCClient struct {
 	socket  string
 	timeout time.Duration
+	clock   Clock
+	onError func(error)
+	metrics Metrics
+	mode    DispatchMode
+	queue   chan Event    // Events waiting for dispatch in DispatchQueue mode
+	stop    chan struct{} // Closed by Close to stop the dispatchQueue goroutine
 	comm    chan *request
 
-	mu     sync.Mutex
-	reqMap map[int]*request  // Maps RequestIDs to Requests for response association
-	event  map[string]func() // Event handle function
+	mu        sync.Mutex
+	conn      net.Conn
+	connected bool                      // A connection was made before, see start
+	lost      chan struct{}             // Closed when conn fails
+	done      chan struct{}             // Closed when the client is closed, mpv shuts down or conn fails
+	doneErr   error                     // Why done was closed, see Err
+	reqMap    map[int]*request          // Maps RequestIDs to Requests for response association
+	event     map[string][]eventHandler // Event handle functions in registration order
+	nextID    int                       // ID of the next registered eventHandler
+}
+
+// eventHandler is a registered event handle function.
+type eventHandler struct {
+	id int
+	fn func(Event)
+}
+
+// IPCOption configures an IPCClient.
+type IPCOption func(*IPCClient)
+
+// WithClock sets the clock used for timeouts and connection retries. A
+// Client, its throttled observers and a Watchdog on this client use it too.
+func WithClock(clock Clock) IPCOption {
+	return func(c *IPCClient) {
+		c.clock = clock
+	}
+}
+
+// WithErrorHandler sets the function receiving errors which can not be
+// returned to a caller, like a PanicError of an event handler.
+// By default they are written to the standard logger.
+func WithErrorHandler(fn func(error)) IPCOption {
+	return func(c *IPCClient) {
+		c.onError = fn
+	}
+}
+
+// DispatchMode controls how event handlers are called.
+type DispatchMode int
+
+// Dispatch modes
+const (
+	// DispatchGoroutine calls the handlers of each event on a new goroutine.
+	// A slow handler delays nothing else, but events may be handled out of order.
+	DispatchGoroutine DispatchMode = iota
+	// DispatchQueue calls all handlers on a single goroutine in the order the
+	// events arrived. Events are queued while a handler is busy and dropped
+	// with ErrEventQueueFull if the queue is full.
+	DispatchQueue
+	// DispatchSync calls the handlers directly while reading from mpv, so no
+	// responses are read until they return. Handlers must not call Exec.
+	DispatchSync
+)
+
+// eventQueueSize is the number of events queued in DispatchQueue mode.
+const eventQueueSize = 256
+
+// ErrEventQueueFull is reported to the error handler if an event is dropped
+// because the handlers don't keep up in DispatchQueue mode.
+var ErrEventQueueFull = errors.New("Event queue full")
+
+// WithDispatchMode sets how event handlers are called, DispatchGoroutine by default.
+func WithDispatchMode(mode DispatchMode) IPCOption {
+	return func(c *IPCClient) {
+		c.mode = mode
+	}
 }
 
 // NewIPCClient creates a new IPCClient connected to the given socket.
-func NewIPCClient(socket string) *IPCClient {
+func NewIPCClient(socket string, opts ...IPCOption) *IPCClient {
+	c := newIPCClient(socket, opts)
+	c.run()
+	return c
+}
+
+// newIPCClient creates an IPCClient which is not connected yet.
+func newIPCClient(socket string, opts []IPCOption) *IPCClient {
 	c := &IPCClient{
 		socket:  socket,
 		timeout: 2 * time.Second,
+		clock:   SystemClock,
+		onError: func(err error) { log.Print(err) },
+		metrics: nopMetrics{},
 		comm:    make(chan *request),
 		reqMap:  make(map[int]*request),
-		event:   make(map[string]func()),
+		event:   make(map[string][]eventHandler),
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.mode == DispatchQueue {
+		c.queue = make(chan Event, eventQueueSize)
+		c.stop = make(chan struct{})
+		go c.dispatchQueue(c.stop)
 	}
-	c.run()
 	return c
 }
 
-//Register Event Handle Function
-func (c *IPCClient) RegisterEvent(name string, fn func()) {
+// RegisterEvent registers a handler for the event. Several handlers can be
+// registered for the same event, they are called in registration order.
+// Handlers registered for EventAll receive every event, after the handlers
+// of the specific event. The returned function removes this handler.
+func (c *IPCClient) RegisterEvent(name string, fn func(Event)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	id := c.nextID
+	c.event[name] = append(c.event[name], eventHandler{id: id, fn: fn})
+	return func() {
+		c.removeHandler(name, id)
+	}
+}
+
+// removeHandler removes the handler with the id from the event.
+func (c *IPCClient) removeHandler(name string, id int) {
 	c.mu.Lock()
-	c.event[name] = fn
+	defer c.mu.Unlock()
+	handlers := c.event[name]
+	for i, h := range handlers {
+		if h.id == id {
+			// Copy, dispatchEvent may still iterate the old slice
+			c.event[name] = append(handlers[:i:i], handlers[i+1:]...)
+			break
+		}
+	}
+	if len(c.event[name]) == 0 {
+		delete(c.event, name)
+	}
+}
+
+// UnregisterEvent removes all handlers registered for the event, including
+// those a Client installed on this lowlevel client. Use Client.UnregisterEvent
+// to remove only the handlers registered through the Client.
+func (c *IPCClient) UnregisterEvent(name string) {
+	c.mu.Lock()
+	delete(c.event, name)
 	c.mu.Unlock()
 }
 
@@ -94,44 +248,254 @@ func (c *IPCClient) RegisterEvent(name string, fn func()) {
 func (c *IPCClient) dispatch(resp *Response) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if resp.Event == "" { // No Event
-		if req, ok := c.reqMap[resp.RequestID]; ok { // Lookup requestID in request map
-			delete(c.reqMap, resp.RequestID)
-			req.Response <- resp
-			return
+	if req, ok := c.reqMap[resp.RequestID]; ok { // Lookup requestID in request map
+		delete(c.reqMap, resp.RequestID)
+		req.Response <- resp
+		return
+	}
+	// Discard response
+}
+
+// dispatchEvent calls the handlers registered for the event.
+// ev.Raw may point into the reused read buffer, it is copied only if there
+// are handlers to receive it.
+func (c *IPCClient) dispatchEvent(ev Event) {
+	handlers := c.handlers(ev.Name)
+	if len(handlers) == 0 {
+		return
+	}
+	if ev.Raw != nil {
+		ev.Raw = append(json.RawMessage(nil), ev.Raw...)
+	}
+	switch c.mode {
+	case DispatchQueue:
+		select {
+		case c.queue <- ev:
+		default:
+			c.onError(ErrEventQueueFull)
 		}
-		// Discard response
-	} else { // Event
-		// TODO: Implement Event support
-		if fn, ok := c.event[resp.Event]; ok {
-			go fn()
+	case DispatchSync:
+		c.callHandlers(ev, handlers)
+	default:
+		go c.callHandlers(ev, handlers)
+	}
+}
+
+// dispatchQueue calls the handlers of queued events in DispatchQueue mode.
+// It returns when stop is closed.
+func (c *IPCClient) dispatchQueue(stop <-chan struct{}) {
+	for {
+		select {
+		case ev := <-c.queue:
+			c.callHandlers(ev, c.handlers(ev.Name))
+		case <-stop:
+			return
 		}
 	}
 }
 
+// handlers returns the handlers registered for the event, including EventAll handlers.
+func (c *IPCClient) handlers(name string) []eventHandler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	named := c.event[name]
+	// Capped, so appending copies instead of writing into the registry
+	return append(named[:len(named):len(named)], c.event[EventAll]...)
+}
+
+func (c *IPCClient) callHandlers(ev Event, handlers []eventHandler) {
+	for _, h := range handlers {
+		c.callHandler(h.fn, ev)
+	}
+}
+
+// PanicError is reported to the error handler if an event handler panics.
+type PanicError struct {
+	Event string      // Name of the event being handled
+	Value interface{} // Value passed to panic
+	Stack []byte      // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("mpv: handler for event %s panicked: %v\n%s", e.Event, e.Value, e.Stack)
+}
+
+// callHandler calls fn, recovering a panic so the client keeps working.
+func (c *IPCClient) callHandler(fn func(Event), ev Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.onError(&PanicError{Event: ev.Name, Value: r, Stack: debug.Stack()})
+		}
+	}()
+	fn(ev)
+}
+
 func (c *IPCClient) run() {
+	conn, err := c.dial(c.socket)
+	if err != nil {
+		panic(err)
+	}
+	c.start(conn)
+}
+
+// Close closes the connection to mpv. Pending and further requests fail
+// with ErrClosed.
+func (c *IPCClient) Close() error {
+	c.markDone(ErrClosed)
+	c.mu.Lock()
+	conn := c.conn
+	if c.stop != nil {
+		select {
+		case <-c.stop:
+		default:
+			close(c.stop)
+		}
+	}
+	c.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// Done returns a channel which is closed when mpv shuts down, the connection
+// to mpv is lost or the client is closed. After a successful Reconnect,
+// Done returns a new channel.
+func (c *IPCClient) Done() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
+
+// Err returns nil while Done is open. Afterwards it returns why Done was
+// closed: ErrShutdown, ErrConnectionLost or ErrClosed.
+func (c *IPCClient) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.doneErr
+}
+
+// Clock returns the clock set with WithClock, SystemClock by default.
+func (c *IPCClient) Clock() Clock {
+	return c.clock
+}
+
+// markDone closes the done channel and drops pending requests, their
+// callers get ErrClosed. err is the reason returned by Err, the first
+// reason is kept.
+func (c *IPCClient) markDone(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.markDoneLocked(err)
+}
+
+// markDoneLocked is markDone with c.mu held.
+func (c *IPCClient) markDoneLocked(err error) {
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+		c.doneErr = err
+	}
+	c.reqMap = make(map[int]*request)
+}
+
+// dial connects to the socket, retrying a few times if mpv is not yet listening.
+func (c *IPCClient) dial(socket string) (net.Conn, error) {
 	count := 0
-	var conn net.Conn
-	var err error
 	for {
-		conn, err = net.Dial("unix", c.socket)
+		conn, err := net.Dial("unix", socket)
 		if err == nil {
-			break
+			return conn, nil
 		}
-		time.Sleep(100 * time.Millisecond)
+		c.clock.Sleep(100 * time.Millisecond)
 		count++
 		if count > 5 {
-			panic(err)
+			return nil, err
+		}
+	}
+}
+
+// start runs the read and write loops on conn until the connection fails.
+func (c *IPCClient) start(conn net.Conn) {
+	done := make(chan struct{})
+	c.mu.Lock()
+	c.conn = conn
+	c.connected = true
+	c.lost = done
+	c.mu.Unlock()
+	go c.readloop(conn, done)
+	go c.writeloop(conn, done)
+}
+
+// connectionLost returns a channel which is closed when the current connection fails.
+func (c *IPCClient) connectionLost() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lost
+}
+
+// Reconnect drops the current connection and connects to the socket again,
+// e.g. after mpv was restarted. Registered event handlers are kept and
+// EventReconnected is dispatched once the new connection is up.
+func (c *IPCClient) Reconnect() error {
+	c.mu.Lock()
+	socket := c.socket
+	c.mu.Unlock()
+	if err := c.connect(socket); err != nil {
+		return err
+	}
+	c.dispatchEvent(Event{Name: EventReconnected})
+	return nil
+}
+
+// connect drops the current connection and connects to socket.
+func (c *IPCClient) connect(socket string) error {
+	c.mu.Lock()
+	old := c.conn
+	c.conn = nil // Dropped on purpose, the readloop must not mark the client done
+	c.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	conn, err := c.dial(socket)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	reconnected := c.connected // False for the first connection of a FailoverClient
+	c.socket = socket
+	select {
+	case <-c.done: // Reopen
+		c.done = make(chan struct{})
+		c.doneErr = nil
+	default:
+	}
+	if c.stop != nil {
+		select {
+		case <-c.stop: // Closed, restart the dispatcher
+			c.stop = make(chan struct{})
+			go c.dispatchQueue(c.stop)
+		default:
 		}
 	}
-	go c.readloop(conn)
-	go c.writeloop(conn)
-	// TODO: Close connection
+	c.mu.Unlock()
+	if reconnected {
+		c.metrics.Reconnected()
+	}
+	c.start(conn)
+	return nil
 }
 
-func (c *IPCClient) writeloop(conn io.Writer) {
+func (c *IPCClient) writeloop(conn io.Writer, done <-chan struct{}) {
 	for {
-		req, ok := <-c.comm
+		var req *request
+		var ok bool
+		select {
+		case req, ok = <-c.comm:
+		case <-done:
+			return
+		}
 		if !ok {
 			panic("Communication channel closed")
 		}
@@ -156,13 +520,17 @@ func (c *IPCClient) writeloop(conn io.Writer) {
 	}
 }
 
-func (c *IPCClient) readloop(conn io.Reader) {
+func (c *IPCClient) readloop(conn io.Reader, done chan<- struct{}) {
+	defer close(done)
 	rd := bufio.NewReader(conn)
+	var buf []byte
 	for {
-		data, err := rd.ReadBytes('\n')
+		data, err := readLine(rd, buf[:0])
+		buf = data
 		if err != nil {
-			// TODO: Handle error
-			continue
+			// Connection is gone, stop the writeloop as well
+			c.connectionFailed(conn)
+			return
 		}
 		var resp Response
 		err = json.Unmarshal(data, &resp)
@@ -170,7 +538,45 @@ func (c *IPCClient) readloop(conn io.Reader) {
 			// TODO: Handle error
 			continue
 		}
-		c.dispatch(&resp)
+		if resp.Event == "" {
+			c.dispatch(&resp)
+			continue
+		}
+		c.metrics.EventReceived(resp.Event)
+		if resp.Event == EventShutDown {
+			c.markDone(ErrShutdown)
+		}
+		// data is reused by the next read, dispatchEvent copies it for handlers
+		c.dispatchEvent(Event{
+			Name: resp.Event,
+			Data: resp.Data,
+			Raw:  bytes.TrimSpace(data),
+		})
+	}
+}
+
+// connectionFailed marks the client done if conn is still its connection,
+// so pending and further requests fail with ErrClosed instead of timing out.
+// Connections replaced by connect are ignored.
+func (c *IPCClient) connectionFailed(conn io.Reader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil || io.Reader(c.conn) != conn {
+		return
+	}
+	c.markDoneLocked(ErrConnectionLost)
+}
+
+// readLine reads a newline terminated line from rd, appending it to buf.
+// The line is read into the reused buffer instead of a fresh allocation per
+// response, so the returned slice is only valid until the next call.
+func readLine(rd *bufio.Reader, buf []byte) ([]byte, error) {
+	for {
+		frag, err := rd.ReadSlice('\n')
+		buf = append(buf, frag...)
+		if err != bufio.ErrBufferFull {
+			return buf, err
+		}
 	}
 }
 
@@ -180,26 +586,113 @@ var (
 	ErrTimeoutRecv = errors.New("Timeout while receiving response")
 )
 
+// ErrClosed is returned by Exec if the client was closed, mpv shut down or
+// the connection to mpv was lost.
+var ErrClosed = errors.New("Client closed")
+
+// Reasons returned by Err after Done was closed, besides ErrClosed
+var (
+	ErrShutdown       = errors.New("mpv shut down")
+	ErrConnectionLost = errors.New("Connection to mpv lost")
+)
+
+// commandName returns the name of a command for metrics and errors.
+func commandName(command []interface{}) string {
+	if len(command) == 0 {
+		return ""
+	}
+	if named, ok := command[0].(map[string]interface{}); ok {
+		return fmt.Sprint(named["name"])
+	}
+	return fmt.Sprint(command[0])
+}
+
 // Exec executes a command via ipc and returns the response.
 // A request can timeout while sending or while waiting for the response.
 // An error is only returned if there was an error in the communication.
 // The client has to check for `response.Error` in case the server returned
 // an error.
+// A single map[string]interface{} argument is sent as a command with named
+// arguments, e.g. {"name": "subprocess", "args": [...], "capture_stdout": true}.
 func (c *IPCClient) Exec(command ...interface{}) (*Response, error) {
+	done := c.Done()
+	select {
+	case <-done:
+		return nil, ErrClosed
+	default:
+	}
+
+	name := commandName(command)
 	req := newRequest(command...)
 	select {
 	case c.comm <- req:
-	case <-time.After(c.timeout):
+	case <-done:
+		return nil, ErrClosed
+	case <-c.clock.After(c.timeout):
+		c.metrics.Timeout(name)
 		return nil, ErrTimeoutSend
 	}
+	c.metrics.CommandSent(name)
+	sent := c.clock.Now()
 
 	select {
 	case res, ok := <-req.Response:
 		if !ok {
 			panic("Response channel closed")
 		}
+		c.metrics.ResponseReceived(name, c.clock.Now().Sub(sent))
 		return res, nil
-	case <-time.After(c.timeout):
+	case <-done:
+		return nil, ErrClosed
+	case <-c.clock.After(c.timeout):
+		c.metrics.Timeout(name)
 		return nil, ErrTimeoutRecv
 	}
 }
+
+// ExecContext executes a command asynchronously in mpv, so long running
+// commands like subprocess or screenshot-to-file on a slow disk don't block
+// other commands. It waits for the response until ctx is done instead of
+// the client's timeout, and returns ctx.Err() then.
+// The JSON IPC can't abort a running command, on cancellation the command
+// continues in mpv and its response is discarded.
+func (c *IPCClient) ExecContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	done := c.Done()
+	select {
+	case <-done:
+		return nil, ErrClosed
+	default:
+	}
+
+	name := commandName(command)
+	req := newRequest(command...)
+	req.Async = true
+	select {
+	case c.comm <- req:
+	case <-done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.clock.After(c.timeout):
+		c.metrics.Timeout(name)
+		return nil, ErrTimeoutSend
+	}
+	c.metrics.CommandSent(name)
+	sent := c.clock.Now()
+
+	select {
+	case res, ok := <-req.Response:
+		if !ok {
+			panic("Response channel closed")
+		}
+		c.metrics.ResponseReceived(name, c.clock.Now().Sub(sent))
+		return res, nil
+	case <-done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.reqMap, req.RequestID)
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,101 @@
+package mpv
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// propertyChange is a property-change event line as sent by mpv.
+const propertyChange = `{"event":"property-change","id":1,"name":"time-pos","data":12.345678}` + "\n"
+
+// benchmarkReadloop feeds b.N property-change lines through the readloop of c.
+func benchmarkReadloop(b *testing.B, c *IPCClient) {
+	input := bytes.Repeat([]byte(propertyChange), b.N)
+	b.SetBytes(int64(len(propertyChange)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	c.readloop(bytes.NewReader(input), make(chan struct{}))
+}
+
+func BenchmarkReadloop(b *testing.B) {
+	b.Run("NoHandler", func(b *testing.B) {
+		benchmarkReadloop(b, newIPCClient("", nil))
+	})
+	b.Run("Handler", func(b *testing.B) {
+		c := newIPCClient("", []IPCOption{WithDispatchMode(DispatchSync)})
+		var n int
+		c.RegisterEvent(EventPropertyChange, func(Event) { n++ })
+		benchmarkReadloop(b, c)
+		if n != b.N {
+			b.Fatalf("Handler called %d times, expected %d", n, b.N)
+		}
+	})
+}
+
+func TestDoneOnConnectionLoss(t *testing.T) {
+	dir, err := os.MkdirTemp("", "mpv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ln, err := net.Listen("unix", filepath.Join(dir, "socket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			conn.Close() // Gone without a shutdown event
+		}
+	}()
+
+	c := NewIPCClient(ln.Addr().String())
+	defer c.Close()
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done not closed after the connection was lost")
+	}
+	if _, err := c.Exec("get_time_us"); err != ErrClosed {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+}
+
+// countMetrics counts reconnects.
+type countMetrics struct {
+	nopMetrics
+	reconnects int32
+}
+
+func (m *countMetrics) Reconnected() {
+	atomic.AddInt32(&m.reconnects, 1)
+}
+
+func TestReconnectedMetrics(t *testing.T) {
+	f := newFakeMPV(t, behaviorOf("mpv 0.38.0", 38))
+	m := &countMetrics{}
+	fc, err := NewFailoverClient([]string{f.ln.Addr().String()}, WithMetrics(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fc.Close()
+	if n := atomic.LoadInt32(&m.reconnects); n != 0 {
+		t.Fatalf("Expected no reconnect after the first connection, got %d", n)
+	}
+
+	m = &countMetrics{}
+	c := NewIPCClient(f.ln.Addr().String(), WithMetrics(m))
+	defer c.Close()
+	if err := c.Reconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&m.reconnects); n != 1 {
+		t.Fatalf("Expected one reconnect, got %d", n)
+	}
+}
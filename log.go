@@ -0,0 +1,100 @@
+package mpv
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+)
+
+// LogLevel is the level of a log message. More severe levels are smaller.
+type LogLevel int
+
+// Log levels
+const (
+	LogLevelNone   LogLevel = iota // No messages, only used to disable logging
+	LogLevelFatal                  // Fatal errors
+	LogLevelError                  // Errors
+	LogLevelWarn                   // Warnings
+	LogLevelInfo                   // Informational messages
+	LogLevelStatus                 // Status line and similar output
+	LogLevelV                      // Verbose messages
+	LogLevelDebug                  // Debug messages
+	LogLevelTrace                  // Very noisy debug messages
+)
+
+var logLevels = []string{"no", "fatal", "error", "warn", "info", "status", "v", "debug", "trace"}
+
+// ParseLogLevel parses a level string as used by mpv.
+// It returns false if the level is unknown.
+func ParseLogLevel(s string) (LogLevel, bool) {
+	for i, name := range logLevels {
+		if name == s {
+			return LogLevel(i), true
+		}
+	}
+	return LogLevelNone, false
+}
+
+func (l LogLevel) String() string {
+	if l < 0 || int(l) >= len(logLevels) {
+		return logLevels[LogLevelNone]
+	}
+	return logLevels[l]
+}
+
+// UnmarshalJSON decodes the level string sent by mpv. Unknown levels are
+// decoded as LogLevelInfo.
+func (l *LogLevel) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	level, ok := ParseLogLevel(s)
+	if !ok {
+		level = LogLevelInfo
+	}
+	*l = level
+	return nil
+}
+
+// EnableLogMessages makes mpv send log messages of the given level and more
+// severe ones as EventLogMessage, e.g. LogLevelWarn for warnings and errors.
+// LogLevelNone disables log messages again.
+func (c *Client) EnableLogMessages(level LogLevel) error {
+	_, err := c.exec("request_log_messages", level.String())
+	if err == nil {
+		atomic.StoreInt32(c.logLevel, int32(level))
+	}
+	return err
+}
+
+// LogMessages returns a channel receiving the log messages enabled by
+// EnableLogMessages and a function to cancel the subscription, which closes
+// the channel.
+func (c *Client) LogMessages() (<-chan LogMessageEvent, func()) {
+	events, cancelEvents := c.Events(EventLogMessage)
+	msgs := make(chan LogMessageEvent, 16)
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		defer close(msgs)
+		for ev := range events {
+			var msg LogMessageEvent
+			if err := json.Unmarshal(ev.Raw, &msg); err != nil {
+				continue
+			}
+			select {
+			case msgs <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			cancelEvents()
+		})
+	}
+	return msgs, cancel
+}
@@ -0,0 +1,113 @@
+package mpv
+
+import "time"
+
+// SetABLoop loops playback between a and b.
+func (c *Client) SetABLoop(a, b time.Duration) error {
+	if err := c.SetProperty("ab-loop-a", a.Seconds()); err != nil {
+		return err
+	}
+	return c.SetProperty("ab-loop-b", b.Seconds())
+}
+
+// ClearABLoop stops the A-B loop.
+func (c *Client) ClearABLoop() error {
+	if err := c.SetProperty("ab-loop-a", "no"); err != nil {
+		return err
+	}
+	return c.SetProperty("ab-loop-b", "no")
+}
+
+// ABLoop returns the A-B loop points. ok is false unless both are set.
+func (c *Client) ABLoop() (a, b time.Duration, ok bool, err error) {
+	props, err := c.GetProperties("ab-loop-a", "ab-loop-b")
+	if err != nil {
+		return 0, 0, false, err
+	}
+	fa, okA := props["ab-loop-a"].(float64) // "no" if unset
+	fb, okB := props["ab-loop-b"].(float64)
+	if !okA || !okB {
+		return 0, 0, false, nil
+	}
+	return seconds(fa), seconds(fb), true, nil
+}
+
+// SetABLoopCount repeats the A-B loop n times before playback continues
+// past B, or forever for LoopInfinite. It returns ErrUnsupported before
+// mpv 0.36.
+func (c *Client) SetABLoopCount(n int) error {
+	if err := c.requireVersion(0, 36); err != nil {
+		return err
+	}
+	if n < 0 {
+		return c.SetProperty("ab-loop-count", "inf")
+	}
+	return c.SetProperty("ab-loop-count", n)
+}
+
+// ToggleABLoop runs the ab-loop command like the default "l" key binding:
+// it sets A at the current position, then B, then clears the loop.
+func (c *Client) ToggleABLoop() error {
+	return c.command("ab-loop")
+}
+
+// LoopInfinite loops forever when passed to SetFileLoop or SetPlaylistLoop.
+const LoopInfinite = -1
+
+// LoopState is the state of loop-file or loop-playlist.
+type LoopState struct {
+	Enabled  bool
+	Count    int  // Number of loops, 0 if disabled or infinite
+	Infinite bool // Loops forever
+}
+
+// SetFileLoop plays the current file n more times, forever for
+// LoopInfinite or only once for 0.
+func (c *Client) SetFileLoop(n int) error {
+	return c.SetProperty("loop-file", loopValue(n))
+}
+
+// SetPlaylistLoop plays the playlist n more times, forever for
+// LoopInfinite or only once for 0.
+func (c *Client) SetPlaylistLoop(n int) error {
+	return c.SetProperty("loop-playlist", loopValue(n))
+}
+
+// FileLoopState returns the loop-file state.
+func (c *Client) FileLoopState() (LoopState, error) {
+	return c.loopState("loop-file")
+}
+
+// PlaylistLoopState returns the loop-playlist state.
+func (c *Client) PlaylistLoopState() (LoopState, error) {
+	return c.loopState("loop-playlist")
+}
+
+func loopValue(n int) interface{} {
+	switch {
+	case n < 0:
+		return "inf"
+	case n == 0:
+		return "no"
+	}
+	return n
+}
+
+func (c *Client) loopState(name string) (LoopState, error) {
+	res, err := c.getProperty(name)
+	if err != nil {
+		return LoopState{}, err
+	}
+	switch v := res.Data.(type) {
+	case bool: // false if disabled
+		return LoopState{Enabled: v, Infinite: v}, nil
+	case string:
+		if v == "inf" || v == "yes" {
+			return LoopState{Enabled: true, Infinite: true}, nil
+		}
+		return LoopState{}, nil
+	case float64:
+		return LoopState{Enabled: v > 0, Count: int(v)}, nil
+	}
+	return LoopState{}, ErrInvalidType
+}
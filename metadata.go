@@ -0,0 +1,59 @@
+package mpv
+
+import "strings"
+
+// Metadata returns the metadata tags of the current file, e.g. "Artist" or
+// "title". The case of the keys depends on the file format.
+func (c *Client) Metadata() (map[string]string, error) {
+	return c.metadata("metadata")
+}
+
+// FilteredMetadata is like Metadata but only returns the tags selected by
+// mpv's display-tags option.
+func (c *Client) FilteredMetadata() (map[string]string, error) {
+	return c.metadata("filtered-metadata")
+}
+
+func (c *Client) metadata(property string) (map[string]string, error) {
+	var m map[string]string
+	if err := c.GetPropertyInto(property, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MetadataValue returns the tag of the current file with the given key,
+// ignoring case, or "" if it is not set.
+func (c *Client) MetadataValue(key string) (string, error) {
+	m, err := c.Metadata()
+	if err != nil {
+		return "", err
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, nil
+		}
+	}
+	return "", nil
+}
+
+// Artist returns the artist tag of the current file.
+func (c *Client) Artist() (string, error) {
+	return c.MetadataValue("artist")
+}
+
+// Album returns the album tag of the current file.
+func (c *Client) Album() (string, error) {
+	return c.MetadataValue("album")
+}
+
+// Title returns the title tag of the current file. Unlike MediaTitle it
+// does not fall back to the filename.
+func (c *Client) Title() (string, error) {
+	return c.MetadataValue("title")
+}
+
+// Date returns the date tag of the current file.
+func (c *Client) Date() (string, error) {
+	return c.MetadataValue("date")
+}
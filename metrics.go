@@ -0,0 +1,29 @@
+package mpv
+
+import "time"
+
+// Metrics receives counters and timings of an IPCClient, to export them to
+// a monitoring system like Prometheus or StatsD. Implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	CommandSent(command string)
+	ResponseReceived(command string, latency time.Duration)
+	Timeout(command string)
+	EventReceived(name string)
+	Reconnected()
+}
+
+// WithMetrics sets the metrics receiver of the client.
+func WithMetrics(m Metrics) IPCOption {
+	return func(c *IPCClient) {
+		c.metrics = m
+	}
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) CommandSent(string)                     {}
+func (nopMetrics) ResponseReceived(string, time.Duration) {}
+func (nopMetrics) Timeout(string)                         {}
+func (nopMetrics) EventReceived(string)                   {}
+func (nopMetrics) Reconnected()                           {}
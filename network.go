@@ -0,0 +1,85 @@
+package mpv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetUserAgent sets the user agent of HTTP requests.
+func (c *Client) SetUserAgent(userAgent string) error {
+	return c.SetProperty("user-agent", userAgent)
+}
+
+// SetHTTPHeaders sets additional header fields of HTTP requests, e.g.
+// {"Authorization": "Bearer ..."}. It replaces headers set before.
+func (c *Client) SetHTTPHeaders(headers map[string]string) error {
+	return c.SetProperty("http-header-fields", headerFields(headers))
+}
+
+// SetReferrer sets the referrer of HTTP requests.
+func (c *Client) SetReferrer(referrer string) error {
+	return c.SetProperty("referrer", referrer)
+}
+
+// SetTLSVerify enables verifying the certificates of TLS connections. mpv
+// does not verify them by default.
+func (c *Client) SetTLSVerify(verify bool) error {
+	return c.SetProperty("tls-verify", verify)
+}
+
+// SetNetworkTimeout sets the timeout of network connections, 0 uses
+// ffmpeg's default.
+func (c *Client) SetNetworkTimeout(d time.Duration) error {
+	return c.SetProperty("network-timeout", d.Seconds())
+}
+
+// NetworkOptions are network options for a single file, see
+// LoadFileOptions. Empty fields are left unchanged.
+type NetworkOptions struct {
+	UserAgent string
+	Headers   map[string]string
+	Referrer  string
+	TLSVerify bool
+	Timeout   time.Duration
+}
+
+// Options returns the options to pass to LoadFileOptions.
+func (o NetworkOptions) Options() map[string]string {
+	opts := map[string]string{}
+	if o.UserAgent != "" {
+		opts["user-agent"] = o.UserAgent
+	}
+	if len(o.Headers) > 0 {
+		fields := headerFields(o.Headers)
+		for i, f := range fields {
+			fields[i] = listEscaper.Replace(f)
+		}
+		opts["http-header-fields"] = strings.Join(fields, ",")
+	}
+	if o.Referrer != "" {
+		opts["referrer"] = o.Referrer
+	}
+	if o.TLSVerify {
+		opts["tls-verify"] = "yes"
+	}
+	if o.Timeout > 0 {
+		opts["network-timeout"] = strconv.FormatFloat(o.Timeout.Seconds(), 'f', -1, 64)
+	}
+	return opts
+}
+
+// listEscaper escapes an item of a comma separated string list, e.g. a
+// header like "Cookie: a=1, b=2".
+var listEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`)
+
+// headerFields formats headers as "Name: value" fields sorted by name.
+func headerFields(headers map[string]string) []string {
+	fields := make([]string, 0, len(headers))
+	for name, value := range headers {
+		fields = append(fields, name+": "+value)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,222 @@
+package mpv
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// observeCount allocates observe ids. They are unique across all clients,
+// as every client sharing a connection sees all property-change events.
+var observeCount int64
+
+// observers routes property-change events to the callbacks registered
+// with Client.ObserveProperty, keyed by observe id.
+type observers struct {
+	mu         sync.Mutex
+	props      map[int]observer
+	registered bool // Event handler registered with the lowlevel client
+}
+
+// observer is a property observed by ObserveProperty.
+type observer struct {
+	name string
+	fn   func(value interface{})
+	stop func() // Stops a pending throttled call, nil if not throttled
+}
+
+func newObservers() *observers {
+	return &observers{
+		props: make(map[int]observer),
+	}
+}
+
+// dispatch calls the callback observing the property of a property-change event.
+func (o *observers) dispatch(ev Event) {
+	var change PropertyChangeEvent
+	if err := json.Unmarshal(ev.Raw, &change); err != nil {
+		return
+	}
+	o.mu.Lock()
+	p, ok := o.props[change.ID]
+	o.mu.Unlock()
+	if ok {
+		p.fn(ev.Data)
+	}
+}
+
+// ObserveOption configures a property observer.
+type ObserveOption func(*observeConfig)
+
+type observeConfig struct {
+	interval time.Duration
+}
+
+// Throttle coalesces changes so the callback is called at most once per
+// interval. The first change is passed on immediately, later changes within
+// the interval are collapsed into a single call with the latest value at its
+// end. Useful for time-pos, which changes many times per second.
+func Throttle(interval time.Duration) ObserveOption {
+	return func(cfg *observeConfig) {
+		cfg.interval = interval
+	}
+}
+
+// throttle limits calls of fn to one per interval.
+type throttle struct {
+	fn       func(value interface{})
+	interval time.Duration
+	clock    Clock
+	dispatch func(fn func(Event), ev Event) // Calls delayed changes like event handlers
+
+	mu      sync.Mutex
+	last    time.Time   // Time of the last call
+	timer   Timer       // Scheduled call, nil if none
+	value   interface{} // Value of the scheduled call
+	stopped bool
+}
+
+func (t *throttle) call(value interface{}) {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	if t.timer != nil {
+		t.value = value
+		t.mu.Unlock()
+		return
+	}
+	now := t.clock.Now()
+	if wait := t.interval - now.Sub(t.last); wait > 0 {
+		t.value = value
+		t.timer = t.clock.AfterFunc(wait, t.flush)
+		t.mu.Unlock()
+		return
+	}
+	t.last = now
+	t.mu.Unlock()
+	t.fn(value)
+}
+
+// flush calls fn with the latest value. It runs on the timer's goroutine,
+// so it goes through dispatch to recover a panic of fn.
+func (t *throttle) flush() {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	value := t.value
+	t.timer = nil
+	t.value = nil
+	t.last = t.clock.Now()
+	t.mu.Unlock()
+	t.dispatch(func(Event) { t.fn(value) }, Event{Name: EventPropertyChange, Data: value})
+}
+
+// stop drops a scheduled call and ignores further changes.
+func (t *throttle) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.value = nil
+}
+
+// ObserveProperty calls fn with the new value whenever the property changes.
+// mpv sends the current value right away. The value is nil if the property
+// is unavailable, e.g. time-pos while nothing is playing.
+// It returns the observe id of the property.
+func (c *Client) ObserveProperty(name string, fn func(value interface{}), opts ...ObserveOption) (int, error) {
+	var cfg observeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var stop func()
+	if cfg.interval > 0 {
+		t := &throttle{fn: fn, interval: cfg.interval, clock: c.clock(), dispatch: c.callHandler}
+		fn = t.call
+		stop = t.stop
+	}
+
+	o := c.observers
+	o.mu.Lock()
+	if !o.registered {
+		c.LLClient.RegisterEvent(EventPropertyChange, o.dispatch)
+		o.registered = true
+	}
+	id := int(atomic.AddInt64(&observeCount, 1))
+	o.props[id] = observer{name: name, fn: fn, stop: stop}
+	o.mu.Unlock()
+
+	_, err := c.exec("observe_property", id, name)
+	if err != nil {
+		c.removeObserver(id)
+		return 0, err
+	}
+	return id, nil
+}
+
+// UnobserveProperty stops observing the property with the observe id
+// returned by ObserveProperty. A throttled change which is still pending
+// is dropped.
+func (c *Client) UnobserveProperty(id int) error {
+	c.removeObserver(id)
+	_, err := c.exec("unobserve_property", id)
+	return err
+}
+
+// removeObserver removes the callback of the observe id.
+func (c *Client) removeObserver(id int) {
+	o := c.observers
+	o.mu.Lock()
+	p, ok := o.props[id]
+	delete(o.props, id)
+	o.mu.Unlock()
+	if ok && p.stop != nil {
+		p.stop()
+	}
+}
+
+// unobserveAll stops all observers registered by ObserveProperty.
+func (c *Client) unobserveAll() error {
+	o := c.observers
+	o.mu.Lock()
+	ids := make([]int, 0, len(o.props))
+	for id := range o.props {
+		ids = append(ids, id)
+	}
+	o.mu.Unlock()
+	var firstErr error
+	for _, id := range ids {
+		if err := c.UnobserveProperty(id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// resubscribe restores the property observers and log messages after the
+// connection to mpv was reestablished, as mpv drops them with the old
+// connection. mpv answers each observe_property with the current value, so
+// the observers receive a snapshot of the state they might have missed.
+func (c *Client) resubscribe(Event) {
+	o := c.observers
+	o.mu.Lock()
+	names := make(map[int]string, len(o.props))
+	for id, p := range o.props {
+		names[id] = p.name
+	}
+	o.mu.Unlock()
+	for id, name := range names {
+		c.exec("observe_property", id, name)
+	}
+	if level := atomic.LoadInt32(c.logLevel); level >= 0 {
+		c.EnableLogMessages(LogLevel(level))
+	}
+}
@@ -0,0 +1,57 @@
+package mpv
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestThrottlePanicIsReported(t *testing.T) {
+	clock := newFakeClock()
+	var reported error
+	s := newStubClient(WithClock(clock), WithDispatchMode(DispatchSync), WithErrorHandler(func(err error) {
+		reported = err
+	}))
+	c := NewClient(s)
+	calls := 0
+	id, err := c.ObserveProperty("time-pos", func(value interface{}) {
+		calls++
+		if calls == 2 {
+			panic("boom")
+		}
+	}, Throttle(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.emitChange(id, 1)
+	s.emitChange(id, 2)
+	clock.Advance(time.Second) // Panics in the delayed call
+	var perr *PanicError
+	if !errors.As(reported, &perr) || perr.Value != "boom" || perr.Event != EventPropertyChange {
+		t.Fatalf("Expected a PanicError of the delayed call, got %v", reported)
+	}
+}
+
+func TestUnobserveStopsThrottle(t *testing.T) {
+	clock := newFakeClock()
+	s := newStubClient(WithClock(clock), WithDispatchMode(DispatchSync))
+	c := NewClient(s)
+	var got []interface{}
+	id, err := c.ObserveProperty("time-pos", func(value interface{}) {
+		got = append(got, value)
+	}, Throttle(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.emitChange(id, 1)
+	s.emitChange(id, 2) // Delayed until the end of the interval
+	if err := c.UnobserveProperty(id); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	if len(got) != 1 {
+		t.Fatalf("Expected no call after UnobserveProperty, got %v", got)
+	}
+}
@@ -0,0 +1,50 @@
+package mpv
+
+// SetOption sets an option at runtime through the options/ property
+// namespace, for settings which are not available as a property of their
+// own or whose property behaves differently.
+func (c *Client) SetOption(name string, value interface{}) error {
+	return c.SetProperty("options/"+name, value)
+}
+
+// GetOption reads the current value of an option through the options/
+// property namespace.
+func (c *Client) GetOption(name string) (interface{}, error) {
+	res, err := c.getProperty("options/" + name)
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// Modes for SetKeepOpen
+const (
+	KeepOpenNo     = "no"     // Go to the next file or stop at the end
+	KeepOpenYes    = "yes"    // Pause at the end of the last file
+	KeepOpenAlways = "always" // Pause at the end of every file
+)
+
+// SetKeepOpen controls whether mpv pauses instead of ending playback when
+// a file ends.
+func (c *Client) SetKeepOpen(mode string) error {
+	return c.SetProperty("keep-open", mode)
+}
+
+// Modes for SetIdle
+const (
+	IdleNo   = "no"   // Quit when the playlist ends
+	IdleYes  = "yes"  // Stay idle when the playlist ends
+	IdleOnce = "once" // Stay idle on startup only
+)
+
+// SetIdle controls whether mpv quits or stays idle when nothing is left
+// to play.
+func (c *Client) SetIdle(mode string) error {
+	return c.SetProperty("idle", mode)
+}
+
+// SetForceWindow controls whether the window stays open while no video is
+// played, e.g. while idle or for audio files.
+func (c *Client) SetForceWindow(force bool) error {
+	return c.SetProperty("force-window", force)
+}
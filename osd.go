@@ -0,0 +1,42 @@
+package mpv
+
+import "time"
+
+// OSDDimensions are the osd-dimensions property. The margins are the
+// borders between the OSD and the video, e.g. black bars.
+type OSDDimensions struct {
+	Width        int     `json:"w"`
+	Height       int     `json:"h"`
+	PixelAspect  float64 `json:"par"`
+	Aspect       float64 `json:"aspect"`
+	MarginTop    int     `json:"mt"`
+	MarginBottom int     `json:"mb"`
+	MarginLeft   int     `json:"ml"`
+	MarginRight  int     `json:"mr"`
+}
+
+// OSDDimensions returns the size of the OSD and the margins of the video
+// within it, to position overlays relative to the video.
+func (c *Client) OSDDimensions() (*OSDDimensions, error) {
+	d := &OSDDimensions{}
+	if err := c.GetPropertyInto("osd-dimensions", d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ShowText shows msg on the OSD for duration, or for osd-duration if duration
+// is 0. The message is only shown if the osd-level is at least level, mpv
+// uses 1 by default.
+func (c *Client) ShowText(msg string, duration time.Duration, level int) error {
+	ms := int64(-1)
+	if duration > 0 {
+		ms = duration.Milliseconds()
+	}
+	return c.command("show-text", msg, ms, level)
+}
+
+// ShowProgress shows the progress bar and the playback position on the OSD.
+func (c *Client) ShowProgress() error {
+	return c.command("show-progress")
+}
@@ -0,0 +1,126 @@
+package mpv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ProbeResult is the metadata of a file extracted by a Prober.
+type ProbeResult struct {
+	Path     string
+	Duration time.Duration
+	Format   string            // Container format, e.g. "matroska,webm"
+	Streams  []ProbeStream     // Streams in file order
+	Tags     map[string]string // Container tags like title or artist
+	Err      error             // Set by ProbeFiles if the file could not be probed
+}
+
+// ProbeStream is a stream of a probed file.
+type ProbeStream struct {
+	Type  string // "video", "audio", "subtitle" or "data"
+	Codec string // e.g. "h264" or "flac"
+}
+
+// Prober extracts the metadata of a file. Implement it to probe with other
+// tools than ffprobe.
+type Prober interface {
+	Probe(ctx context.Context, path string) (ProbeResult, error)
+}
+
+// FFProbe is a Prober running ffprobe, which ships with FFmpeg. It probes
+// files without a running mpv, so the playback of the client is not touched.
+type FFProbe struct {
+	Path string // Path of the ffprobe binary, looked up in PATH if empty
+}
+
+// Probe runs ffprobe on the file. The process is killed when ctx is done.
+func (p FFProbe) Probe(ctx context.Context, path string) (ProbeResult, error) {
+	bin := p.Path
+	if bin == "" {
+		bin = "ffprobe"
+	}
+	out, err := exec.CommandContext(ctx, bin, "-v", "error", "-print_format", "json",
+		"-show_format", "-show_streams", "-i", path).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return ProbeResult{}, fmt.Errorf("ffprobe %s: %w: %s", path, err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return ProbeResult{}, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+	return parseFFProbe(out)
+}
+
+// parseFFProbe decodes the JSON output of ffprobe.
+func parseFFProbe(data []byte) (ProbeResult, error) {
+	var out struct {
+		Format struct {
+			Name     string            `json:"format_name"`
+			Duration string            `json:"duration"` // Seconds as a decimal string
+			Tags     map[string]string `json:"tags"`
+		} `json:"format"`
+		Streams []struct {
+			Type  string `json:"codec_type"`
+			Codec string `json:"codec_name"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return ProbeResult{}, err
+	}
+	r := ProbeResult{
+		Format: out.Format.Name,
+		Tags:   out.Format.Tags,
+	}
+	if secs, err := strconv.ParseFloat(out.Format.Duration, 64); err == nil {
+		r.Duration = seconds(secs)
+	}
+	for _, s := range out.Streams {
+		r.Streams = append(r.Streams, ProbeStream{Type: s.Type, Codec: s.Codec})
+	}
+	return r, nil
+}
+
+// ProbeFiles probes the files with ffprobe, see ProbeFilesWith.
+func ProbeFiles(ctx context.Context, paths []string, concurrency int) ([]ProbeResult, error) {
+	return ProbeFilesWith(ctx, FFProbe{}, paths, concurrency)
+}
+
+// ProbeFilesWith probes the files with p, running up to concurrency probes
+// at once. The results are in the order of paths. The error of a file which
+// could not be probed is set in its ProbeResult.Err. The returned error is
+// only set if ctx is done or concurrency is not positive.
+func ProbeFilesWith(ctx context.Context, p Prober, paths []string, concurrency int) ([]ProbeResult, error) {
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("probe concurrency %d: %w", concurrency, ErrInvalidValue)
+	}
+	results := make([]ProbeResult, len(paths))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r, err := p.Probe(ctx, path)
+			r.Path = path
+			r.Err = err
+			results[i] = r
+		}(i, path)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,86 @@
+package mpv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProber returns the path as format and records the concurrent probes.
+type fakeProber struct {
+	mu      sync.Mutex
+	running int
+	max     int
+}
+
+var errProbe = errors.New("unreadable")
+
+func (p *fakeProber) Probe(ctx context.Context, path string) (ProbeResult, error) {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.max {
+		p.max = p.running
+	}
+	p.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+	if path == "broken.mkv" {
+		return ProbeResult{}, errProbe
+	}
+	return ProbeResult{Format: path}, nil
+}
+
+func TestProbeFilesWith(t *testing.T) {
+	p := &fakeProber{}
+	paths := []string{"a.mkv", "broken.mkv", "c.mkv", "d.mkv", "e.mkv"}
+	results, err := ProbeFilesWith(context.Background(), p, paths, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r.Path != paths[i] {
+			t.Errorf("Result %d is for %s, expected %s", i, r.Path, paths[i])
+		}
+		if paths[i] == "broken.mkv" {
+			if r.Err != errProbe {
+				t.Errorf("Expected the probe error for %s, got %v", r.Path, r.Err)
+			}
+		} else if r.Err != nil || r.Format != paths[i] {
+			t.Errorf("Unexpected result for %s: %+v", paths[i], r)
+		}
+	}
+	if p.max > 2 {
+		t.Errorf("Expected at most 2 concurrent probes, got %d", p.max)
+	}
+	if _, err := ProbeFilesWith(context.Background(), p, paths, 0); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue for concurrency 0, got %v", err)
+	}
+}
+
+func TestParseFFProbe(t *testing.T) {
+	out := `{
+		"streams": [
+			{"index": 0, "codec_name": "h264", "codec_type": "video"},
+			{"index": 1, "codec_name": "opus", "codec_type": "audio"}
+		],
+		"format": {
+			"format_name": "matroska,webm",
+			"duration": "61.500000",
+			"tags": {"title": "Clip"}
+		}
+	}`
+	r, err := parseFFProbe([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Format != "matroska,webm" || r.Duration != 61500*time.Millisecond || r.Tags["title"] != "Clip" {
+		t.Errorf("Unexpected format: %+v", r)
+	}
+	if len(r.Streams) != 2 || r.Streams[0] != (ProbeStream{"video", "h264"}) || r.Streams[1] != (ProbeStream{"audio", "opus"}) {
+		t.Errorf("Unexpected streams: %+v", r.Streams)
+	}
+}
@@ -0,0 +1,81 @@
+package mpv
+
+import "context"
+
+// Run starts an external program in mpv's context, detached from mpv. The
+// first argument is the program, it is not run through a shell.
+func (c *Client) Run(args ...string) error {
+	return c.command(stringArgs("run", args)...)
+}
+
+// SubprocessOptions control Subprocess.
+type SubprocessOptions struct {
+	CaptureStdout bool // Return the output in SubprocessResult.Stdout
+	CaptureStderr bool // Return the output in SubprocessResult.Stderr
+	// KeepAfterPlayback keeps the process running when playback of the
+	// current file stops. By default mpv kills it then.
+	KeepAfterPlayback bool
+}
+
+// SubprocessResult is the result of the subprocess command.
+type SubprocessResult struct {
+	Status      int    // Exit status, negative if the process could not be run
+	Stdout      []byte // Captured stdout
+	Stderr      []byte // Captured stderr
+	ErrorString string // "" on success, "killed" or "init" otherwise
+	KilledByUs  bool   // True if mpv killed the process, e.g. as playback stopped
+}
+
+// Subprocess runs an external program and waits for it to exit or for ctx
+// to be done. Unlike Run the process is tied to mpv and, unless
+// KeepAfterPlayback is set, to the current file. It runs asynchronously in
+// mpv, so it neither blocks mpv nor is limited by the client timeout. The
+// process is not killed when ctx is done.
+// A non-zero exit status is no error, check SubprocessResult.Status.
+func (c *Client) Subprocess(ctx context.Context, args []string, opts SubprocessOptions) (*SubprocessResult, error) {
+	argv := make([]interface{}, len(args))
+	for i, a := range args {
+		argv[i] = a
+	}
+	res, err := c.execContext(ctx, map[string]interface{}{
+		"name":           "subprocess",
+		"args":           argv,
+		"capture_stdout": opts.CaptureStdout,
+		"capture_stderr": opts.CaptureStderr,
+		"playback_only":  !opts.KeepAfterPlayback,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := res.Data.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidType
+	}
+	r := &SubprocessResult{
+		Stdout: outputBytes(data["stdout"]),
+		Stderr: outputBytes(data["stderr"]),
+	}
+	if status, ok := data["status"].(float64); ok {
+		r.Status = int(status)
+	}
+	r.ErrorString, _ = data["error_string"].(string)
+	r.KilledByUs, _ = data["killed_by_us"].(bool)
+	return r, nil
+}
+
+// outputBytes converts captured output, which mpv encodes as a string or
+// an array of bytes depending on the version.
+func outputBytes(v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return []byte(v)
+	case []interface{}:
+		b := make([]byte, 0, len(v))
+		for _, n := range v {
+			f, _ := n.(float64)
+			b = append(b, byte(f))
+		}
+		return b
+	}
+	return nil
+}
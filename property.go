@@ -0,0 +1,53 @@
+package mpv
+
+// Property is the name of an mpv property. It is an alias of string, so the
+// constants below and plain strings can both be passed to GetProperty,
+// SetProperty, ObserveProperty and the other property methods. Prefer the
+// constants to catch typos at compile time.
+type Property = string
+
+// Commonly used properties, see the mpv manual for all of them.
+const (
+	PropertyPause          Property = "pause"
+	PropertyCoreIdle       Property = "core-idle"
+	PropertyIdleActive     Property = "idle-active"
+	PropertyEOFReached     Property = "eof-reached"
+	PropertySeeking        Property = "seeking"
+	PropertyPausedForCache Property = "paused-for-cache"
+	PropertyTimePos        Property = "time-pos"
+	PropertyTimeRemaining  Property = "time-remaining"
+	PropertyDuration       Property = "duration"
+	PropertyPercentPos     Property = "percent-pos"
+	PropertyVolume         Property = "volume"
+	PropertyMute           Property = "mute"
+	PropertySpeed          Property = "speed"
+	PropertyFullscreen     Property = "fullscreen"
+	PropertyFilename       Property = "filename"
+	PropertyPath           Property = "path"
+	PropertyMediaTitle     Property = "media-title"
+	PropertyFileFormat     Property = "file-format"
+	PropertyFileSize       Property = "file-size"
+	PropertyAudioBitrate   Property = "audio-bitrate"
+	PropertyVideoBitrate   Property = "video-bitrate"
+	PropertyPlaylist       Property = "playlist"
+	PropertyPlaylistPos    Property = "playlist-pos"
+	PropertyPlaylistCount  Property = "playlist-count"
+	PropertyLoopFile       Property = "loop-file"
+	PropertyLoopPlaylist   Property = "loop-playlist"
+	PropertyShuffle        Property = "shuffle"
+	PropertyTrackList      Property = "track-list"
+	PropertyAID            Property = "aid"
+	PropertyVID            Property = "vid"
+	PropertySID            Property = "sid"
+	PropertyAudioDelay     Property = "audio-delay"
+	PropertySubDelay       Property = "sub-delay"
+	PropertyChapter        Property = "chapter"
+	PropertyChapters       Property = "chapters"
+	PropertyChapterList    Property = "chapter-list"
+	PropertyMetadata       Property = "metadata"
+	PropertyVideoParams    Property = "video-params"
+	PropertyAudioParams    Property = "audio-params"
+	PropertyOSDDimensions  Property = "osd-dimensions"
+	PropertyHwdec          Property = "hwdec"
+	PropertyHwdecCurrent   Property = "hwdec-current"
+)
@@ -0,0 +1,12 @@
+package mpv
+
+// StartRecording writes the current stream to path as it is received,
+// without re-encoding. The container format is chosen by the extension.
+func (c *Client) StartRecording(path string) error {
+	return c.SetProperty("stream-record", path)
+}
+
+// StopRecording stops the recording started by StartRecording.
+func (c *Client) StopRecording() error {
+	return c.SetProperty("stream-record", "")
+}
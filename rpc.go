@@ -1,9 +1,19 @@
 package mpv
 
-import "net/rpc"
+import (
+	"encoding/gob"
+	"net/rpc"
+)
 
 var _ LLClient = (*RPCClient)(nil)
 
+func init() {
+	// Types of commands with named arguments, which gob can't send as
+	// interface values unless registered.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
 // RPCServer publishes a LLClient over RPC.
 type RPCServer struct {
 	llclient LLClient
@@ -42,6 +52,10 @@ func (s *RPCClient) Exec(command ...interface{}) (*Response, error) {
 	return &res, err
 }
 
-func (s *RPCClient) RegisterEvent(name string, handle func()) {
+func (s *RPCClient) RegisterEvent(name string, handle func(Event)) func() {
+	return func() {}
+}
+
+func (s *RPCClient) UnregisterEvent(name string) {
 
 }
@@ -0,0 +1,152 @@
+package mpv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+)
+
+// Mode options for Screenshot and ScreenshotToFile
+const (
+	ScreenshotSubtitles = "subtitles" // Video with subtitles, the default
+	ScreenshotVideo     = "video"     // Video only
+	ScreenshotWindow    = "window"    // Window contents including OSD
+)
+
+// Screenshot saves a screenshot to screenshot-directory, named after
+// screenshot-template. An empty mode is ScreenshotSubtitles.
+func (c *Client) Screenshot(mode string) error {
+	if mode == "" {
+		mode = ScreenshotSubtitles
+	}
+	return c.command("screenshot", mode)
+}
+
+// ScreenshotToFile saves a screenshot to path. The image format is chosen
+// by the file extension. An empty mode is ScreenshotSubtitles.
+func (c *Client) ScreenshotToFile(path string, mode string) error {
+	if mode == "" {
+		mode = ScreenshotSubtitles
+	}
+	return c.command("screenshot-to-file", path, mode)
+}
+
+// ScreenshotToFileContext is like ScreenshotToFile but runs asynchronously
+// in mpv and waits until the file is written or ctx is done, e.g. for slow
+// network mounts. The screenshot is still written if ctx is done first.
+func (c *Client) ScreenshotToFileContext(ctx context.Context, path string, mode string) error {
+	if mode == "" {
+		mode = ScreenshotSubtitles
+	}
+	return c.commandContext(ctx, "screenshot-to-file", path, mode)
+}
+
+// rawScreenshot is the result of the screenshot-raw command.
+type rawScreenshot struct {
+	W      int             `json:"w"`
+	H      int             `json:"h"`
+	Stride int             `json:"stride"`
+	Format string          `json:"format"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// ScreenshotRaw takes a screenshot of the video with subtitles and returns
+// it as an image, without writing a file. mpv returns the pixels as a byte
+// array which older versions can't encode for the JSON IPC, there it fails
+// with a CommandError.
+func (c *Client) ScreenshotRaw() (image.Image, error) {
+	res, err := c.exec("screenshot-raw", ScreenshotSubtitles)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	var raw rawScreenshot
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Format != "bgr0" {
+		return nil, ErrInvalidType
+	}
+	var data []byte
+	if err := json.Unmarshal(raw.Data, &data); err != nil {
+		// Not base64, try an array of numbers
+		var ints []int
+		if err := json.Unmarshal(raw.Data, &ints); err != nil {
+			return nil, ErrInvalidType
+		}
+		data = make([]byte, len(ints))
+		for i, v := range ints {
+			data[i] = byte(v)
+		}
+	}
+	return decodeBGR0(raw.W, raw.H, raw.Stride, data)
+}
+
+// decodeBGR0 converts bgr0 pixels to an RGBA image.
+func decodeBGR0(w, h, stride int, data []byte) (image.Image, error) {
+	if w <= 0 || h <= 0 || stride < w*4 || len(data) < stride*(h-1)+w*4 {
+		return nil, ErrInvalidType
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		src := data[y*stride : y*stride+w*4]
+		dst := img.Pix[y*img.Stride : y*img.Stride+w*4]
+		for x := 0; x < w*4; x += 4 {
+			dst[x] = src[x+2]
+			dst[x+1] = src[x+1]
+			dst[x+2] = src[x]
+			dst[x+3] = 0xff
+		}
+	}
+	return img, nil
+}
+
+// ScreenshotConfig configures Screenshot. Empty fields are left unchanged.
+type ScreenshotConfig struct {
+	Directory   string // screenshot-directory
+	Template    string // screenshot-template, e.g. "shot-%n"
+	Format      string // screenshot-format: png, jpg, jpeg, webp, jxl or avif
+	JPEGQuality int    // screenshot-jpeg-quality, 1-100
+}
+
+// screenshotFormats are the values accepted for screenshot-format.
+var screenshotFormats = map[string]bool{
+	"png": true, "jpg": true, "jpeg": true, "webp": true, "jxl": true, "avif": true,
+}
+
+// ConfigureScreenshots sets the screenshot options in cfg. The values are
+// validated before any of them is sent, invalid values return an error
+// wrapping ErrInvalidValue.
+func (c *Client) ConfigureScreenshots(cfg ScreenshotConfig) error {
+	if cfg.Format != "" && !screenshotFormats[cfg.Format] {
+		return fmt.Errorf("screenshot-format %q: %w", cfg.Format, ErrInvalidValue)
+	}
+	if cfg.JPEGQuality < 0 || cfg.JPEGQuality > 100 {
+		return fmt.Errorf("screenshot-jpeg-quality %d: %w", cfg.JPEGQuality, ErrInvalidValue)
+	}
+	if cfg.Directory != "" {
+		if err := c.SetProperty("screenshot-directory", cfg.Directory); err != nil {
+			return err
+		}
+	}
+	if cfg.Template != "" {
+		if err := c.SetProperty("screenshot-template", cfg.Template); err != nil {
+			return err
+		}
+	}
+	if cfg.Format != "" {
+		if err := c.SetProperty("screenshot-format", cfg.Format); err != nil {
+			return err
+		}
+	}
+	if cfg.JPEGQuality != 0 {
+		if err := c.SetProperty("screenshot-jpeg-quality", cfg.JPEGQuality); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package mpv
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+)
+
+// bindingCount numbers the input sections created by Bind.
+var bindingCount int64
+
+// OnScriptMessage calls fn for every client-message whose first argument is
+// name, e.g. sent with "script-message name arg1 arg2" from a key binding or
+// a Lua script. fn receives the remaining arguments.
+// The returned function removes the handler.
+func (c *Client) OnScriptMessage(name string, fn func(args []string)) func() {
+	return c.LLClient.RegisterEvent(EventClientMessage, func(ev Event) {
+		var msg ClientMessageEvent
+		if err := json.Unmarshal(ev.Raw, &msg); err != nil {
+			return
+		}
+		if len(msg.Args) == 0 || msg.Args[0] != name {
+			return
+		}
+		fn(msg.Args[1:])
+	})
+}
+
+// Bind binds a key, e.g. "ctrl+s" or "MBTN_RIGHT", to fn. The binding takes
+// precedence over bindings of the user and of scripts. It works by defining
+// an input section which sends a script-message routed to fn.
+// The returned function removes the binding.
+func (c *Client) Bind(key string, fn func()) (unbind func() error, err error) {
+	name := "mpv-go-binding-" + strconv.FormatInt(atomic.AddInt64(&bindingCount, 1), 10)
+	unregister := c.OnScriptMessage(name, func([]string) {
+		fn()
+	})
+	if _, err := c.exec("define-section", name, key+" script-message "+name, "force"); err != nil {
+		unregister()
+		return nil, err
+	}
+	if _, err := c.exec("enable-section", name); err != nil {
+		unregister()
+		return nil, err
+	}
+	unbind = func() error {
+		unregister()
+		if _, err := c.exec("disable-section", name); err != nil {
+			return err
+		}
+		_, err := c.exec("define-section", name, "")
+		return err
+	}
+	return unbind, nil
+}
+
+// ScriptMessage sends a script-message to all scripts and clients, e.g. to
+// trigger a function a Lua script registered with mp.register_script_message.
+func (c *Client) ScriptMessage(args ...string) error {
+	return c.command(stringArgs("script-message", args)...)
+}
+
+// ScriptMessageTo sends a script-message to the script or client named target.
+func (c *Client) ScriptMessageTo(target string, args ...string) error {
+	return c.command(stringArgs("script-message-to", append([]string{target}, args...))...)
+}
+
+// ScriptBinding activates a named key binding of a script, e.g.
+// "stats/display-stats-toggle", like pressing the key bound to it.
+func (c *Client) ScriptBinding(name string) error {
+	return c.command("script-binding", name)
+}
+
+// stringArgs returns a command with string arguments.
+func stringArgs(name string, args []string) []interface{} {
+	cmd := make([]interface{}, 0, len(args)+1)
+	cmd = append(cmd, name)
+	for _, a := range args {
+		cmd = append(cmd, a)
+	}
+	return cmd
+}
@@ -0,0 +1,95 @@
+package mpv
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+)
+
+// Errors related to socket paths
+var (
+	ErrNotSocket         = errors.New("Not a socket")
+	ErrSocketPathTooLong = errors.New("Socket path too long")
+)
+
+// maxSocketPath is the usable length of sun_path, 108 bytes on Linux and
+// 104 on macOS and the BSDs, minus the terminating NUL.
+func maxSocketPath() int {
+	if runtime.GOOS == "linux" {
+		return 107
+	}
+	return 103
+}
+
+// TempSocketPath allocates a unique path to pass to mpv's
+// --input-ipc-server option.
+//
+// On Windows it returns a named pipe. Elsewhere the socket is placed in a new
+// directory only accessible by the current user, inside XDG_RUNTIME_DIR if
+// set, otherwise the temp directory. If that path exceeds the sun_path limit,
+// which happens with the long temp directories on macOS, /tmp is used instead.
+// Remove the directory with os.RemoveAll(filepath.Dir(path)) when done.
+func TempSocketPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf(`\\.\pipe\mpv-%d-%d`, os.Getpid(), rand.Int63()), nil
+	}
+	base := os.Getenv("XDG_RUNTIME_DIR")
+	if base == "" {
+		base = os.TempDir()
+	}
+	path, err := tempSocketIn(base)
+	if err == ErrSocketPathTooLong && base != "/tmp" {
+		path, err = tempSocketIn("/tmp")
+	}
+	return path, err
+}
+
+// tempSocketIn creates a private directory in base and returns a socket path inside it.
+func tempSocketIn(base string) (string, error) {
+	const name = "mpv.sock"
+	// Check before creating anything, MkdirTemp appends a random suffix
+	// of at most 10 characters to the pattern.
+	if len(filepath.Join(base, "mpv-0123456789", name)) > maxSocketPath() {
+		return "", ErrSocketPathTooLong
+	}
+	dir, err := os.MkdirTemp(base, "mpv-")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// RemoveStaleSocket removes the socket file at path if nobody is listening
+// on it anymore, e.g. because the mpv owning it crashed. Call it before
+// launching mpv with --input-ipc-server=path. It reports whether the file
+// was removed; missing files and live sockets are left untouched.
+func RemoveStaleSocket(path string) (bool, error) {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return false, ErrNotSocket
+	}
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return false, nil
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return false, err
+	}
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	return true, nil
+}
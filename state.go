@@ -0,0 +1,101 @@
+package mpv
+
+import "sync/atomic"
+
+// cachedProperties are observed by CachedState.
+var cachedProperties = []string{"pause", "time-pos", "duration", "volume", "playlist-pos", "media-title"}
+
+// CachedState serves core properties from memory. It observes them and
+// caches every change, so reads are lock-free and need no IPC round trip.
+// Values are zero while a property is unavailable.
+type CachedState struct {
+	client *Client
+	ids    []int
+	values map[string]*atomic.Value // Hold cachedValue, keyed by property name
+}
+
+// cachedValue wraps property values, as atomic.Value can't store nil.
+type cachedValue struct {
+	v interface{}
+}
+
+// NewCachedState starts observing the core properties of the client.
+func NewCachedState(c *Client) (*CachedState, error) {
+	s := &CachedState{
+		client: c,
+		values: make(map[string]*atomic.Value, len(cachedProperties)),
+	}
+	for _, name := range cachedProperties {
+		v := &atomic.Value{}
+		v.Store(cachedValue{})
+		s.values[name] = v
+	}
+	for _, name := range cachedProperties {
+		v := s.values[name]
+		id, err := c.ObserveProperty(name, func(value interface{}) {
+			v.Store(cachedValue{value})
+		})
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s.ids = append(s.ids, id)
+	}
+	return s, nil
+}
+
+// Close stops observing the properties.
+func (s *CachedState) Close() error {
+	var firstErr error
+	for _, id := range s.ids {
+		if err := s.client.UnobserveProperty(id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.ids = nil
+	return firstErr
+}
+
+func (s *CachedState) get(name string) interface{} {
+	return s.values[name].Load().(cachedValue).v
+}
+
+func (s *CachedState) float(name string) float64 {
+	f, _ := s.get(name).(float64)
+	return f
+}
+
+// IsPause returns true if the player is paused.
+func (s *CachedState) IsPause() bool {
+	b, _ := s.get("pause").(bool)
+	return b
+}
+
+// Position returns the playback position in seconds.
+func (s *CachedState) Position() float64 {
+	return s.float("time-pos")
+}
+
+// Duration returns the duration of the current file in seconds.
+func (s *CachedState) Duration() float64 {
+	return s.float("duration")
+}
+
+// Volume returns the volume level.
+func (s *CachedState) Volume() int {
+	return int(s.float("volume"))
+}
+
+// PlayPos returns the playlist position, -1 if no entry is selected.
+func (s *CachedState) PlayPos() int {
+	if _, ok := s.get("playlist-pos").(float64); !ok {
+		return -1
+	}
+	return int(s.float("playlist-pos"))
+}
+
+// MediaTitle returns the title of the current file.
+func (s *CachedState) MediaTitle() string {
+	t, _ := s.get("media-title").(string)
+	return t
+}
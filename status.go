@@ -0,0 +1,68 @@
+package mpv
+
+// Status is a snapshot of the playback state. Unavailable properties, e.g.
+// the position while nothing is playing, are left zero.
+type Status struct {
+	Pause         bool
+	Position      float64 // Seconds
+	Duration      float64 // Seconds
+	Percent       float64 // Percent of the duration, 0-100
+	Volume        float64
+	Mute          bool
+	Speed         float64
+	Filename      string
+	Path          string
+	MediaTitle    string
+	PlaylistPos   int // -1 if no entry is playing
+	PlaylistCount int
+	FileLoop      bool
+	PlaylistLoop  bool
+}
+
+// statusProperties are read by Status.
+var statusProperties = []string{
+	"pause", "time-pos", "duration", "percent-pos", "volume", "mute", "speed",
+	"filename", "path", "media-title", "playlist-pos", "playlist-count",
+	"loop-file", "loop-playlist",
+}
+
+// Status reads the playback state in one batch with GetProperties, so the
+// values are requested together instead of one round trip each.
+func (c *Client) Status() (*Status, error) {
+	props, err := c.GetProperties(statusProperties...)
+	if err != nil {
+		return nil, err
+	}
+	float := func(name string) float64 {
+		f, _ := props[name].(float64)
+		return f
+	}
+	boolean := func(name string) bool {
+		b, _ := props[name].(bool)
+		return b
+	}
+	str := func(name string) string {
+		s, _ := props[name].(string)
+		return s
+	}
+	s := &Status{
+		Pause:         boolean("pause"),
+		Position:      float("time-pos"),
+		Duration:      float("duration"),
+		Percent:       float("percent-pos"),
+		Volume:        float("volume"),
+		Mute:          boolean("mute"),
+		Speed:         float("speed"),
+		Filename:      str("filename"),
+		Path:          str("path"),
+		MediaTitle:    str("media-title"),
+		PlaylistPos:   -1,
+		PlaylistCount: int(float("playlist-count")),
+		FileLoop:      props["loop-file"] == "inf",     // false or a loop count otherwise
+		PlaylistLoop:  props["loop-playlist"] == "inf", // false or a loop count otherwise
+	}
+	if pos, ok := props["playlist-pos"].(float64); ok {
+		s.PlaylistPos = int(pos)
+	}
+	return s, nil
+}
@@ -0,0 +1,147 @@
+package mpv
+
+// SetSubDelay sets the subtitle delay in seconds. Positive values show
+// subtitles later.
+func (c *Client) SetSubDelay(seconds float64) error {
+	return c.SetProperty("sub-delay", seconds)
+}
+
+// AdjustSubDelay adds seconds to the subtitle delay.
+func (c *Client) AdjustSubDelay(seconds float64) error {
+	return c.command("add", "sub-delay", seconds)
+}
+
+// SubDelay returns the subtitle delay in seconds.
+func (c *Client) SubDelay() (float64, error) {
+	return c.GetFloatProperty("sub-delay")
+}
+
+// SetSubPos sets the vertical subtitle position in percent of the screen
+// height, 0 is the top and 100 the bottom.
+func (c *Client) SetSubPos(percent float64) error {
+	return c.SetProperty("sub-pos", percent)
+}
+
+// SubPos returns the vertical subtitle position in percent of the screen height.
+func (c *Client) SubPos() (float64, error) {
+	return c.GetFloatProperty("sub-pos")
+}
+
+// SetSubScale sets the subtitle font size factor, 1 is the default size.
+func (c *Client) SetSubScale(scale float64) error {
+	return c.SetProperty("sub-scale", scale)
+}
+
+// SubScale returns the subtitle font size factor.
+func (c *Client) SubScale() (float64, error) {
+	return c.GetFloatProperty("sub-scale")
+}
+
+// SubSeek seeks to the start of the n-th next subtitle line, or the n-th
+// previous one for negative n. SubSeek(0) replays the current line.
+func (c *Client) SubSeek(n int) error {
+	return c.command("sub-seek", n)
+}
+
+// SubStep changes the subtitle delay so that the n-th next subtitle line,
+// or the n-th previous one for negative n, is shown now.
+func (c *Client) SubStep(n int) error {
+	return c.command("sub-step", n)
+}
+
+// SubStyle is the appearance of text subtitles. Colors are "#RRGGBB" or
+// "#AARRGGBB". ApplySubStyle leaves empty strings and nil fields unchanged,
+// the numeric fields are pointers so they can be set to 0, e.g. to turn
+// off the border or the shadow.
+type SubStyle struct {
+	Font        string   // sub-font
+	Size        *float64 // sub-font-size, in scaled pixels of a 720 pixel high window
+	Color       string   // sub-color
+	BorderColor string   // sub-border-color
+	BorderSize  *float64 // sub-border-size, 0 disables the border
+	ShadowColor string   // sub-shadow-color
+	Shadow      *float64 // sub-shadow-offset, 0 disables the shadow
+	MarginY     *int     // sub-margin-y, distance from the bottom
+}
+
+// ApplySubStyle sets the non-empty fields of s.
+func (c *Client) ApplySubStyle(s SubStyle) error {
+	props := []struct {
+		name  string
+		value interface{}
+		set   bool
+	}{
+		{"sub-font", s.Font, s.Font != ""},
+		{"sub-font-size", s.Size, s.Size != nil},
+		{"sub-color", s.Color, s.Color != ""},
+		{"sub-border-color", s.BorderColor, s.BorderColor != ""},
+		{"sub-border-size", s.BorderSize, s.BorderSize != nil},
+		{"sub-shadow-color", s.ShadowColor, s.ShadowColor != ""},
+		{"sub-shadow-offset", s.Shadow, s.Shadow != nil},
+		{"sub-margin-y", s.MarginY, s.MarginY != nil},
+	}
+	for _, p := range props {
+		if !p.set {
+			continue
+		}
+		if err := c.SetProperty(p.name, p.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CurrentSubStyle returns the current subtitle appearance. Fields of
+// unavailable properties are left empty.
+func (c *Client) CurrentSubStyle() (*SubStyle, error) {
+	props, err := c.GetProperties("sub-font", "sub-font-size", "sub-color", "sub-border-color",
+		"sub-border-size", "sub-shadow-color", "sub-shadow-offset", "sub-margin-y")
+	if err != nil {
+		return nil, err
+	}
+	float := func(name string) *float64 {
+		if f, ok := props[name].(float64); ok {
+			return &f
+		}
+		return nil
+	}
+	s := &SubStyle{
+		Size:       float("sub-font-size"),
+		BorderSize: float("sub-border-size"),
+		Shadow:     float("sub-shadow-offset"),
+	}
+	s.Font, _ = props["sub-font"].(string)
+	s.Color, _ = props["sub-color"].(string)
+	s.BorderColor, _ = props["sub-border-color"].(string)
+	s.ShadowColor, _ = props["sub-shadow-color"].(string)
+	if margin, ok := props["sub-margin-y"].(float64); ok {
+		m := int(margin)
+		s.MarginY = &m
+	}
+	return s, nil
+}
+
+// SetSubVisibility shows or hides subtitles without changing the selected track.
+func (c *Client) SetSubVisibility(visible bool) error {
+	return c.SetProperty("sub-visibility", visible)
+}
+
+// SetSecondarySubVisibility shows or hides the secondary subtitles.
+func (c *Client) SetSecondarySubVisibility(visible bool) error {
+	return c.SetProperty("secondary-sub-visibility", visible)
+}
+
+// Modes for SetSubAssOverride
+const (
+	SubAssOverrideNo    = "no"    // Use the styling of ASS subtitles
+	SubAssOverrideYes   = "yes"   // Apply some sub-* options, the default
+	SubAssOverrideScale = "scale" // Like SubAssOverrideYes and apply sub-scale
+	SubAssOverrideForce = "force" // Apply all sub-* options
+	SubAssOverrideStrip = "strip" // Strip all styling
+)
+
+// SetSubAssOverride controls how far the sub-* style options override the
+// styling of ASS subtitles.
+func (c *Client) SetSubAssOverride(mode string) error {
+	return c.SetProperty("sub-ass-override", mode)
+}
@@ -0,0 +1,66 @@
+package mpv
+
+// Track types
+const (
+	TrackVideo    = "video"
+	TrackAudio    = "audio"
+	TrackSubtitle = "sub"
+)
+
+// Track is an entry of the track-list property.
+type Track struct {
+	Type     string  `json:"type"` // TrackVideo, TrackAudio or TrackSubtitle
+	ID       int     `json:"id"`   // ID as used by the vid, aid and sid properties
+	Title    string  `json:"title"`
+	Lang     string  `json:"lang"`
+	Codec    string  `json:"codec"`
+	Default  bool    `json:"default"`
+	Selected bool    `json:"selected"`
+	Channels int     `json:"demux-channel-count"` // Audio only
+	DemuxW   int     `json:"demux-w"`             // Video only
+	DemuxH   int     `json:"demux-h"`             // Video only
+	FPS      float64 `json:"demux-fps"`           // Video only
+}
+
+// Tracks returns the video, audio and subtitle tracks of the current file.
+func (c *Client) Tracks() ([]Track, error) {
+	var tracks []Track
+	if err := c.GetPropertyInto("track-list", &tracks); err != nil {
+		return nil, err
+	}
+	return tracks, nil
+}
+
+// SelectedTrack returns the selected track of the given type, or nil if
+// no track of the type is selected.
+func (c *Client) SelectedTrack(trackType string) (*Track, error) {
+	tracks, err := c.Tracks()
+	if err != nil {
+		return nil, err
+	}
+	return selectedTrack(tracks, trackType), nil
+}
+
+// SelectedVideoTrack returns the selected video track or nil.
+func (c *Client) SelectedVideoTrack() (*Track, error) {
+	return c.SelectedTrack(TrackVideo)
+}
+
+// SelectedAudioTrack returns the selected audio track or nil.
+func (c *Client) SelectedAudioTrack() (*Track, error) {
+	return c.SelectedTrack(TrackAudio)
+}
+
+// SelectedSubtitleTrack returns the selected subtitle track or nil.
+func (c *Client) SelectedSubtitleTrack() (*Track, error) {
+	return c.SelectedTrack(TrackSubtitle)
+}
+
+func selectedTrack(tracks []Track, trackType string) *Track {
+	for i := range tracks {
+		if tracks[i].Type == trackType && tracks[i].Selected {
+			return &tracks[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package mpv
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned by methods which need a newer mpv than the one
+// connected. It also matches a PropertyError for a property this mpv version
+// does not know. Check for it with errors.Is.
+var ErrUnsupported = errors.New("Unsupported by this mpv version")
+
+// requireVersion returns ErrUnsupported if mpv is older than major.minor.
+// Versions which can not be parsed, e.g. of custom builds, are assumed to be
+// recent enough, mpv reports an error itself if they are not.
+func (c *Client) requireVersion(major, minor int) error {
+	res, err := c.getProperty("mpv-version")
+	if err != nil {
+		return err
+	}
+	version, _ := res.Data.(string)
+	ma, mi, ok := parseVersion(version)
+	if !ok {
+		return nil
+	}
+	if ma < major || ma == major && mi < minor {
+		return ErrUnsupported
+	}
+	return nil
+}
+
+// parseVersion parses the major and minor version of an mpv-version string
+// like "mpv 0.38.0" or "mpv v0.37.0-543-g5a4b1d9".
+func parseVersion(version string) (major, minor int, ok bool) {
+	version = strings.TrimPrefix(version, "mpv ")
+	version = strings.TrimPrefix(version, "v")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(strings.TrimRightFunc(parts[1], func(r rune) bool {
+		return r < '0' || r > '9'
+	}))
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,411 @@
+package mpv
+
+// VideoParams are the video-params property, describing the decoded video
+// after filtering.
+type VideoParams struct {
+	PixelFormat   string  `json:"pixelformat"`
+	HWPixelFormat string  `json:"hw-pixelformat"` // Only set for hardware decoding
+	Width         int     `json:"w"`
+	Height        int     `json:"h"`
+	DisplayWidth  int     `json:"dw"` // Width scaled by the pixel aspect ratio
+	DisplayHeight int     `json:"dh"` // Height scaled by the pixel aspect ratio
+	Aspect        float64 `json:"aspect"`
+	PixelAspect   float64 `json:"par"`
+	ColorMatrix   string  `json:"colormatrix"`
+	ColorLevels   string  `json:"colorlevels"`
+	Primaries     string  `json:"primaries"`
+	Gamma         string  `json:"gamma"`
+	Rotate        int     `json:"rotate"` // Clockwise rotation in degrees
+}
+
+// VideoParams returns the parameters of the current video.
+func (c *Client) VideoParams() (*VideoParams, error) {
+	p := &VideoParams{}
+	if err := c.GetPropertyInto("video-params", p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DisplayNames returns the names of the displays the window is on, e.g.
+// "HDMI-1" on X11 or Wayland.
+func (c *Client) DisplayNames() ([]string, error) {
+	var names []string
+	if err := c.GetPropertyInto("display-names", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// DisplayFPS returns the refresh rate of the display as reported by the system.
+func (c *Client) DisplayFPS() (float64, error) {
+	return c.GetFloatProperty("display-fps")
+}
+
+// EstimatedDisplayFPS returns the refresh rate of the display measured by
+// mpv. It is only available in display-sync mode.
+func (c *Client) EstimatedDisplayFPS() (float64, error) {
+	return c.GetFloatProperty("estimated-display-fps")
+}
+
+// IsVOConfigured returns true if the video output is configured, i.e. a
+// window is open and video is displayed.
+func (c *Client) IsVOConfigured() (bool, error) {
+	return c.GetBoolProperty("vo-configured")
+}
+
+// CurrentVO returns the name of the active video output, e.g. "gpu".
+func (c *Client) CurrentVO() (string, error) {
+	return c.GetStringProperty("current-vo")
+}
+
+// ContainerFPS returns the frame rate stored in the container.
+func (c *Client) ContainerFPS() (float64, error) {
+	return c.GetFloatProperty("container-fps")
+}
+
+// EstimatedVFFPS returns the frame rate measured after the video filters.
+func (c *Client) EstimatedVFFPS() (float64, error) {
+	return c.GetFloatProperty("estimated-vf-fps")
+}
+
+// VideoCodec returns a description of the video codec, e.g.
+// "H.264 / AVC / MPEG-4 AVC / MPEG-4 part 10".
+func (c *Client) VideoCodec() (string, error) {
+	return c.GetStringProperty("video-codec")
+}
+
+// VideoFormat returns the short name of the video codec, e.g. "h264".
+func (c *Client) VideoFormat() (string, error) {
+	return c.GetStringProperty("video-format")
+}
+
+// HwdecCurrent returns the hardware decoding API in use, e.g. "vaapi", or
+// "no" if the video is decoded in software.
+func (c *Client) HwdecCurrent() (string, error) {
+	return c.GetStringProperty("hwdec-current")
+}
+
+// Hardware decoding modes for SetHwdec
+const (
+	HwdecAuto     = "auto"      // Use a safe hardware decoder if available
+	HwdecAutoCopy = "auto-copy" // Like HwdecAuto, but copy frames back to system memory
+	HwdecNo       = "no"        // Decode in software
+	HwdecVAAPI    = "vaapi"
+	HwdecNVDEC    = "nvdec"
+)
+
+// SetHwdec sets the hardware decoding mode. It takes effect at runtime, check
+// HwdecCurrent to see whether hardware decoding is actually active.
+func (c *Client) SetHwdec(mode string) error {
+	return c.SetProperty("hwdec", mode)
+}
+
+// Hwdec returns the requested hardware decoding mode.
+func (c *Client) Hwdec() (string, error) {
+	return c.GetStringProperty("hwdec")
+}
+
+// FrameStep shows the next frame and pauses.
+func (c *Client) FrameStep() error {
+	return c.command("frame-step")
+}
+
+// FrameBackStep shows the previous frame and pauses. Stepping back is slow,
+// as mpv has to seek and decode from the previous keyframe.
+func (c *Client) FrameBackStep() error {
+	return c.command("frame-back-step")
+}
+
+// VideoTransform is the zoom, pan and rotation applied to the video.
+type VideoTransform struct {
+	Zoom   float64 // video-zoom, log2 of the scale factor: 0 is unscaled, 1 doubles the size
+	PanX   float64 // video-pan-x, in units of the video width
+	PanY   float64 // video-pan-y, in units of the video height
+	Rotate int     // video-rotate, clockwise in degrees
+}
+
+// VideoTransform returns the current zoom, pan and rotation.
+func (c *Client) VideoTransform() (*VideoTransform, error) {
+	props, err := c.GetProperties("video-zoom", "video-pan-x", "video-pan-y", "video-rotate")
+	if err != nil {
+		return nil, err
+	}
+	t := &VideoTransform{}
+	t.Zoom, _ = props["video-zoom"].(float64)
+	t.PanX, _ = props["video-pan-x"].(float64)
+	t.PanY, _ = props["video-pan-y"].(float64)
+	if rotate, ok := props["video-rotate"].(float64); ok {
+		t.Rotate = int(rotate)
+	}
+	return t, nil
+}
+
+// SetVideoZoom sets the zoom as log2 of the scale factor, e.g. 1 doubles
+// the size and -1 halves it.
+func (c *Client) SetVideoZoom(zoom float64) error {
+	return c.SetProperty("video-zoom", zoom)
+}
+
+// SetVideoPan moves the video by x times its width and y times its height.
+func (c *Client) SetVideoPan(x, y float64) error {
+	if err := c.SetProperty("video-pan-x", x); err != nil {
+		return err
+	}
+	return c.SetProperty("video-pan-y", y)
+}
+
+// SetVideoRotate rotates the video clockwise by deg degrees. Negative
+// values rotate counterclockwise.
+func (c *Client) SetVideoRotate(deg int) error {
+	deg %= 360
+	if deg < 0 {
+		deg += 360
+	}
+	return c.SetProperty("video-rotate", deg)
+}
+
+// ResetVideoTransform resets the zoom, pan and rotation.
+func (c *Client) ResetVideoTransform() error {
+	if err := c.SetVideoZoom(0); err != nil {
+		return err
+	}
+	if err := c.SetVideoPan(0, 0); err != nil {
+		return err
+	}
+	return c.SetVideoRotate(0)
+}
+
+// VideoEqualizer are the video equalizer settings, each in -100..100.
+type VideoEqualizer struct {
+	Brightness int
+	Contrast   int
+	Saturation int
+	Gamma      int
+	Hue        int
+}
+
+// VideoEqualizer returns the video equalizer settings.
+func (c *Client) VideoEqualizer() (*VideoEqualizer, error) {
+	props, err := c.GetProperties("brightness", "contrast", "saturation", "gamma", "hue")
+	if err != nil {
+		return nil, err
+	}
+	get := func(name string) int {
+		f, _ := props[name].(float64)
+		return int(f)
+	}
+	return &VideoEqualizer{
+		Brightness: get("brightness"),
+		Contrast:   get("contrast"),
+		Saturation: get("saturation"),
+		Gamma:      get("gamma"),
+		Hue:        get("hue"),
+	}, nil
+}
+
+// setEqualizer sets a video equalizer property, clamped to -100..100.
+func (c *Client) setEqualizer(name string, n int) error {
+	if n < -100 {
+		n = -100
+	} else if n > 100 {
+		n = 100
+	}
+	return c.SetProperty(name, n)
+}
+
+func (c *Client) equalizer(name string) (int, error) {
+	f, err := c.GetFloatProperty(name)
+	return int(f), err
+}
+
+// SetBrightness sets the brightness, clamped to -100..100.
+func (c *Client) SetBrightness(n int) error {
+	return c.setEqualizer("brightness", n)
+}
+
+// Brightness returns the brightness.
+func (c *Client) Brightness() (int, error) {
+	return c.equalizer("brightness")
+}
+
+// SetContrast sets the contrast, clamped to -100..100.
+func (c *Client) SetContrast(n int) error {
+	return c.setEqualizer("contrast", n)
+}
+
+// Contrast returns the contrast.
+func (c *Client) Contrast() (int, error) {
+	return c.equalizer("contrast")
+}
+
+// SetSaturation sets the saturation, clamped to -100..100.
+func (c *Client) SetSaturation(n int) error {
+	return c.setEqualizer("saturation", n)
+}
+
+// Saturation returns the saturation.
+func (c *Client) Saturation() (int, error) {
+	return c.equalizer("saturation")
+}
+
+// SetGamma sets the gamma, clamped to -100..100.
+func (c *Client) SetGamma(n int) error {
+	return c.setEqualizer("gamma", n)
+}
+
+// Gamma returns the gamma.
+func (c *Client) Gamma() (int, error) {
+	return c.equalizer("gamma")
+}
+
+// SetHue sets the hue, clamped to -100..100.
+func (c *Client) SetHue(n int) error {
+	return c.setEqualizer("hue", n)
+}
+
+// Hue returns the hue.
+func (c *Client) Hue() (int, error) {
+	return c.equalizer("hue")
+}
+
+// Deinterlace modes for SetDeinterlace
+const (
+	DeinterlaceYes  = "yes"
+	DeinterlaceNo   = "no"
+	DeinterlaceAuto = "auto" // Only deinterlace frames flagged as interlaced, mpv 0.38 and later
+)
+
+// SetDeinterlace sets the deinterlace mode. DeinterlaceAuto returns
+// ErrUnsupported before mpv 0.38.
+func (c *Client) SetDeinterlace(mode string) error {
+	if mode == DeinterlaceAuto {
+		if err := c.requireVersion(0, 38); err != nil {
+			return err
+		}
+	}
+	return c.SetProperty("deinterlace", mode)
+}
+
+// Deinterlace returns the deinterlace mode.
+func (c *Client) Deinterlace() (string, error) {
+	res, err := c.getProperty("deinterlace")
+	if err != nil {
+		return "", err
+	}
+	switch v := res.Data.(type) {
+	case string:
+		return v, nil
+	case bool: // Before mpv 0.38 deinterlace was a flag
+		if v {
+			return DeinterlaceYes, nil
+		}
+		return DeinterlaceNo, nil
+	}
+	return "", ErrInvalidType
+}
+
+// SetAspectOverride overrides the aspect ratio of the video, e.g. "16:9" or
+// "2.35". "0" ignores the aspect ratio stored in the file.
+func (c *Client) SetAspectOverride(ratio string) error {
+	return c.SetProperty("video-aspect-override", ratio)
+}
+
+// ClearAspectOverride restores the aspect ratio stored in the file.
+func (c *Client) ClearAspectOverride() error {
+	return c.SetProperty("video-aspect-override", "-1")
+}
+
+// SetPanscan crops the video to fill the window, from 0 (no cropping) to 1
+// (fill the window completely), e.g. to remove letterboxing.
+func (c *Client) SetPanscan(f float64) error {
+	if f < 0 {
+		f = 0
+	} else if f > 1 {
+		f = 1
+	}
+	return c.SetProperty("panscan", f)
+}
+
+// SetTitle sets the window title. Property references in the template are
+// expanded, e.g. "MyApp - ${media-title}". Note that Title returns the
+// title tag of the file, not the window title.
+func (c *Client) SetTitle(template string) error {
+	return c.SetProperty("title", template)
+}
+
+// Modes for SetVideoSync
+const (
+	VideoSyncAudio             = "audio" // Sync video to the audio, the default
+	VideoSyncDisplayResample   = "display-resample"
+	VideoSyncDisplayResampleVD = "display-resample-vdrop"
+	VideoSyncDisplayResampleDS = "display-resample-desync"
+	VideoSyncDisplayVDrop      = "display-vdrop"
+	VideoSyncDisplayADrop      = "display-adrop"
+	VideoSyncDisplayDesync     = "display-desync"
+	VideoSyncDesync            = "desync"
+)
+
+// SetVideoSync sets how video is synchronized to audio and the display.
+// The display modes are needed for interpolation.
+func (c *Client) SetVideoSync(mode string) error {
+	return c.SetProperty("video-sync", mode)
+}
+
+// SetInterpolation enables frame interpolation, which reduces stutter when
+// the frame rate does not match the display. It needs a display video-sync mode.
+func (c *Client) SetInterpolation(enable bool) error {
+	return c.SetProperty("interpolation", enable)
+}
+
+// SetTscale sets the filter used for interpolation, e.g. "oversample" or "linear".
+func (c *Client) SetTscale(name string) error {
+	return c.SetProperty("tscale", name)
+}
+
+// Tone mapping curves for SetToneMapping
+const (
+	ToneMappingAuto     = "auto"
+	ToneMappingClip     = "clip"
+	ToneMappingMobius   = "mobius"
+	ToneMappingReinhard = "reinhard"
+	ToneMappingHable    = "hable"
+	ToneMappingGamma    = "gamma"
+	ToneMappingLinear   = "linear"
+	ToneMappingSpline   = "spline"
+	ToneMappingBT2390   = "bt.2390"
+	ToneMappingBT2446a  = "bt.2446a"
+)
+
+// SetToneMapping sets the curve used to map HDR content to the display.
+func (c *Client) SetToneMapping(curve string) error {
+	return c.SetProperty("tone-mapping", curve)
+}
+
+// SetToneMappingParam sets the parameter of the tone mapping curve, its
+// meaning depends on the curve.
+func (c *Client) SetToneMappingParam(param float64) error {
+	return c.SetProperty("tone-mapping-param", param)
+}
+
+// Modes for SetHDRComputePeak
+const (
+	HDRComputePeakAuto = "auto"
+	HDRComputePeakYes  = "yes"
+	HDRComputePeakNo   = "no"
+)
+
+// SetHDRComputePeak controls whether the peak brightness of HDR content is
+// measured per frame instead of taken from the metadata.
+func (c *Client) SetHDRComputePeak(mode string) error {
+	return c.SetProperty("hdr-compute-peak", mode)
+}
+
+// SetTargetPeak sets the peak brightness of the display in nits, 0 detects
+// it automatically.
+func (c *Client) SetTargetPeak(nits float64) error {
+	if nits <= 0 {
+		return c.SetProperty("target-peak", "auto")
+	}
+	return c.SetProperty("target-peak", nits)
+}
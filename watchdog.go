@@ -0,0 +1,103 @@
+package mpv
+
+import (
+	"sync"
+	"time"
+)
+
+// Watchdog periodically round-trips a cheap command to mpv and reconnects
+// the IPCClient after repeated failures. Handlers registered for
+// EventReconnected are called after each successful reconnect, so the
+// application can resync its state. Failed restarts and reconnects are
+// reported to the error handler of the client, see WithErrorHandler.
+// The check interval follows the clock of the client.
+type Watchdog struct {
+	client      *IPCClient
+	interval    time.Duration
+	maxFailures int
+
+	// Restart is called before reconnecting if set, e.g. to kill and relaunch
+	// the mpv process. If it returns an error, the reconnect is skipped.
+	Restart func() error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatchdog creates a watchdog checking client every interval. After
+// maxFailures failed checks in a row mpv is considered dead.
+func NewWatchdog(client *IPCClient, interval time.Duration, maxFailures int) *Watchdog {
+	if maxFailures < 1 {
+		maxFailures = 1
+	}
+	return &Watchdog{
+		client:      client,
+		interval:    interval,
+		maxFailures: maxFailures,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start starts watching in the background.
+func (w *Watchdog) Start() {
+	go w.loop()
+}
+
+// Stop stops the watchdog. It does not close the client.
+func (w *Watchdog) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (w *Watchdog) loop() {
+	ticker := w.client.clock.NewTicker(w.interval)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		select {
+		case <-ticker.C():
+		case <-w.stop:
+			return
+		}
+		if w.ping() {
+			failures = 0
+			continue
+		}
+		failures++
+		if failures < w.maxFailures {
+			continue
+		}
+		failures = 0
+		if w.Restart != nil {
+			if err := w.Restart(); err != nil {
+				w.client.onError(&WatchdogError{Op: "restart", Err: err})
+				continue
+			}
+		}
+		if err := w.client.Reconnect(); err != nil {
+			w.client.onError(&WatchdogError{Op: "reconnect", Err: err})
+		}
+	}
+}
+
+// WatchdogError is reported to the error handler of the client if the
+// Watchdog fails to restart mpv or to reconnect.
+type WatchdogError struct {
+	Op  string // "restart" or "reconnect"
+	Err error
+}
+
+func (e *WatchdogError) Error() string {
+	return "mpv: watchdog " + e.Op + ": " + e.Err.Error()
+}
+
+func (e *WatchdogError) Unwrap() error {
+	return e.Err
+}
+
+// ping reports whether mpv answered a get_time_us request.
+func (w *Watchdog) ping() bool {
+	res, err := w.client.Exec("get_time_us")
+	return err == nil && res.Err == "success"
+}
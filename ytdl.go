@@ -0,0 +1,25 @@
+package mpv
+
+// EnableYtdl enables or disables opening URLs of sites like YouTube with
+// youtube-dl or yt-dlp.
+func (c *Client) EnableYtdl(enable bool) error {
+	return c.SetProperty("ytdl", enable)
+}
+
+// SetYtdlFormat sets the format selection passed to youtube-dl or yt-dlp,
+// e.g. "bestvideo[height<=?720]+bestaudio/best". It applies to files
+// loaded afterwards.
+func (c *Client) SetYtdlFormat(format string) error {
+	return c.SetProperty("ytdl-format", format)
+}
+
+// SetYtdlRawOptions sets options passed to youtube-dl or yt-dlp, without
+// the leading dashes, e.g. {"cookies": "/path/cookies.txt"}. Use an empty
+// value for options without argument. It replaces options set before and
+// applies to files loaded afterwards.
+func (c *Client) SetYtdlRawOptions(options map[string]string) error {
+	if options == nil {
+		options = map[string]string{}
+	}
+	return c.SetProperty("ytdl-raw-options", options)
+}